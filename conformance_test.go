@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+// conformanceSuite файл conformance/v1.json.
+type conformanceSuite struct {
+	ModelVersion int               `json:"model_version"`
+	Tolerance    float64           `json:"tolerance"`
+	Cases        []conformanceCase `json:"cases"`
+}
+
+// conformanceCase один случай из файла с эталонными значениями.
+type conformanceCase struct {
+	Name  string `json:"name"`
+	Input struct {
+		Type                string  `json:"type"`
+		Action              int     `json:"action"`
+		LenStep             float64 `json:"len_step_m"`
+		DurationMin         float64 `json:"duration_min"`
+		Weight              float64 `json:"weight_kg"`
+		Height              float64 `json:"height_cm"`
+		Cadence             float64 `json:"cadence"`
+		TreadmillDistanceKm float64 `json:"treadmill_distance_km"`
+		MeasuredDistanceKm  float64 `json:"measured_distance_km"`
+		LengthPool          int     `json:"length_pool_m"`
+		CountPool           int     `json:"count_pool"`
+		Age                 int     `json:"age"`
+		Poles               bool    `json:"poles"`
+		BackpackWeight      float64 `json:"backpack_weight_kg"`
+		Meters              int     `json:"meters"`
+		SplitS              float64 `json:"split_s"`
+		Environment         *struct {
+			Temperature float64 `json:"temperature_c"`
+			Surface     Surface `json:"surface"`
+			Wind        float64 `json:"wind_ms"`
+		} `json:"environment"`
+		Formula *struct {
+			MeanSpeedMultiplier float64 `json:"mean_speed_multiplier"`
+			MeanSpeedShift      float64 `json:"mean_speed_shift"`
+		} `json:"formula"`
+	} `json:"input"`
+	Expected struct {
+		DistanceKm float64 `json:"distance_km"`
+		SpeedKmh   float64 `json:"speed_kmh"`
+		Calories   float64 `json:"calories"`
+	} `json:"expected"`
+}
+
+// training возвращает тренировку, описанную входными данными случая.
+func (c conformanceCase) training(t *testing.T) CaloriesCalculator {
+	in := c.Input
+	training := Training{
+		Action:   in.Action,
+		LenStep:  in.LenStep,
+		Duration: time.Duration(in.DurationMin * float64(time.Minute)),
+		Weight:   in.Weight,
+	}
+	if in.Age != 0 {
+		training.Profile = &UserProfile{Age: in.Age}
+	}
+	var env *Environment
+	if in.Environment != nil {
+		env = &Environment{Temperature: in.Environment.Temperature, Surface: in.Environment.Surface, Wind: in.Environment.Wind}
+	}
+	switch in.Type {
+	case "running":
+		r := Running{Training: training, Cadence: in.Cadence, TreadmillDistance: in.TreadmillDistanceKm,
+			MeasuredDistance: in.MeasuredDistanceKm, Environment: env}
+		if in.Formula != nil {
+			r.Formula = &RunningFormula{MeanSpeedMultiplier: in.Formula.MeanSpeedMultiplier, MeanSpeedShift: in.Formula.MeanSpeedShift}
+		}
+		return r
+	case "walking":
+		return Walking{Training: training, Height: in.Height, Environment: env, Poles: in.Poles, BackpackWeight: in.BackpackWeight}
+	case "swimming":
+		return Swimming{Training: training, LengthPool: in.LengthPool, CountPool: in.CountPool}
+	case "rowing":
+		return Rowing{Training: training, Meters: in.Meters, Split: time.Duration(in.SplitS * float64(time.Second))}
+	}
+	t.Fatalf("%s: неизвестный тип тренировки %q", c.Name, in.Type)
+	return nil
+}
+
+func TestConformanceV1(t *testing.T) {
+	data, err := os.ReadFile("conformance/v1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suite conformanceSuite
+	if err := json.Unmarshal(data, &suite); err != nil {
+		t.Fatal(err)
+	}
+	if suite.ModelVersion != 1 || len(suite.Cases) == 0 {
+		t.Fatalf("model_version = %d, cases = %d", suite.ModelVersion, len(suite.Cases))
+	}
+
+	for _, c := range suite.Cases {
+		info := readInfo(c.training(t))
+		check := func(field string, got, want float64) {
+			if math.Abs(got-want) > suite.Tolerance {
+				t.Errorf("%s: %s = %v, want %v", c.Name, field, got, want)
+			}
+		}
+		check("distance_km", info.Distance, c.Expected.DistanceKm)
+		check("speed_kmh", info.Speed, c.Expected.SpeedKmh)
+		check("calories", info.Calories, c.Expected.Calories)
+	}
+}
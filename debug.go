@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logCalories пишет в Logger тренировки промежуточные значения расчета калорий на уровне Debug.
+// Вызывающий код проверяет Logger на nil до сборки attrs, чтобы без логгера расчет не тратил
+// время и память на отладочные значения.
+func (t Training) logCalories(calories float64, attrs ...slog.Attr) {
+	if !t.Logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	attrs = append(attrs,
+		slog.String("training_type", t.TrainingType),
+		slog.Int("action", t.Action),
+		slog.Float64("len_step", t.LenStep),
+		slog.Duration("duration", t.Duration),
+		slog.Float64("weight", t.Weight),
+		slog.Float64("calories", calories),
+	)
+	t.Logger.LogAttrs(context.Background(), slog.LevelDebug, "расчет калорий", attrs...)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogCaloriesOncePerOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	running := Running{Training: Training{
+		TrainingType: "Бег",
+		Action:       5000,
+		LenStep:      LenStep,
+		Duration:     30 * time.Minute,
+		Weight:       85,
+		StartedAt:    time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC),
+		Logger:       logger,
+	}}
+
+	outputs := map[string]func() error{
+		"ReadData":       func() error { ReadData(running); return nil },
+		"WriteInfo text": func() error { return WriteInfo(io.Discard, running, FormatText) },
+		"WriteInfo json": func() error { return WriteInfo(io.Discard, running, FormatJSON) },
+		"WriteInfo csv":  func() error { return WriteInfo(io.Discard, running, FormatCSV) },
+		"WriteInfoTemplate": func() error {
+			return WriteInfoTemplate(io.Discard, running, InfoTemplate(""))
+		},
+		"WriteICS": func() error { return WriteICS(io.Discard, running) },
+	}
+	for name, output := range outputs {
+		buf.Reset()
+		if err := output(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := strings.Count(buf.String(), "msg=\"расчет калорий\""); got != 1 {
+			t.Errorf("%s wrote %d debug records, want 1:\n%s", name, got, buf.String())
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Допуски, в пределах которых две тренировки считаются одной и той же.
+const (
+	DuplicateStartTolerance    = 2 * time.Minute // разница во времени начала
+	DuplicateDurationTolerance = time.Minute     // разница в продолжительности
+	DuplicateDistanceTolerance = 0.05            // относительная разница в дистанции
+	DuplicateMinDistanceDelta  = 0.05            // разница в дистанции в км, которая допустима всегда
+)
+
+// Fingerprint возвращает отпечаток тренировки: тип, время начала и продолжительность
+// с точностью до минуты и дистанцию с точностью до 100 м.
+// Одинаковые отпечатки означают одну и ту же тренировку, но записи из разных источников
+// могут отличаться на границе округления, поэтому для сравнения используйте IsDuplicate.
+func Fingerprint(info InfoMessage) string {
+	return fmt.Sprintf("%s|%s|%d|%.1f",
+		info.TrainingType,
+		info.StartedAt.UTC().Truncate(time.Minute).Format(time.RFC3339),
+		info.Duration.Round(time.Minute)/time.Minute,
+		info.Distance,
+	)
+}
+
+// IsDuplicate сообщает, что a и b описывают одну и ту же тренировку:
+// тип совпадает, а время начала, продолжительность и дистанция различаются не больше допусков.
+// Тренировки без времени начала дубликатами не считаются.
+func IsDuplicate(a, b InfoMessage) bool {
+	if a.TrainingType != b.TrainingType || a.StartedAt.IsZero() || b.StartedAt.IsZero() {
+		return false
+	}
+	if absDuration(a.StartedAt.Sub(b.StartedAt)) > DuplicateStartTolerance {
+		return false
+	}
+	if absDuration(a.Duration-b.Duration) > DuplicateDurationTolerance {
+		return false
+	}
+	tolerance := math.Max(DuplicateMinDistanceDelta, DuplicateDistanceTolerance*math.Max(a.Distance, b.Distance))
+	return math.Abs(a.Distance-b.Distance) <= tolerance
+}
+
+// absDuration возвращает модуль d.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Deduplicator собирает тренировки из нескольких источников, пропуская дубликаты.
+// Нулевое значение готово к использованию.
+type Deduplicator struct {
+	added   []InfoMessage
+	skipped []InfoMessage
+}
+
+// AddIfNew добавляет тренировку, если среди добавленных нет ее дубликата, и сообщает, была ли она добавлена.
+func (d *Deduplicator) AddIfNew(info InfoMessage) bool {
+	for _, added := range d.added {
+		if IsDuplicate(added, info) {
+			d.skipped = append(d.skipped, info)
+			return false
+		}
+	}
+	d.added = append(d.added, info)
+	return true
+}
+
+// Added возвращает добавленные тренировки в порядке добавления.
+func (d *Deduplicator) Added() []InfoMessage {
+	return d.added
+}
+
+// Skipped возвращает тренировки, пропущенные как дубликаты.
+func (d *Deduplicator) Skipped() []InfoMessage {
+	return d.skipped
+}
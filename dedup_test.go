@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// dedupBase тренировка, с которой сравниваются варианты в тестах дубликатов.
+var dedupBase = InfoMessage{
+	TrainingType: "Бег",
+	StartedAt:    time.Date(2026, 5, 1, 7, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+	Duration:     30 * time.Minute,
+	Distance:     10,
+}
+
+func TestIsDuplicate(t *testing.T) {
+	with := func(change func(*InfoMessage)) InfoMessage {
+		info := dedupBase
+		change(&info)
+		return info
+	}
+	short := with(func(i *InfoMessage) { i.Distance = 0.5 })
+
+	tests := []struct {
+		name string
+		a, b InfoMessage
+		want bool
+	}{
+		{"same", dedupBase, dedupBase, true},
+		{"start 2 min later", dedupBase, with(func(i *InfoMessage) { i.StartedAt = i.StartedAt.Add(2 * time.Minute) }), true},
+		{"start 2 min earlier", dedupBase, with(func(i *InfoMessage) { i.StartedAt = i.StartedAt.Add(-2 * time.Minute) }), true},
+		{"start over 2 min", dedupBase, with(func(i *InfoMessage) { i.StartedAt = i.StartedAt.Add(2*time.Minute + time.Second) }), false},
+		{"duration 1 min longer", dedupBase, with(func(i *InfoMessage) { i.Duration += time.Minute }), true},
+		{"duration over 1 min", dedupBase, with(func(i *InfoMessage) { i.Duration -= time.Minute + time.Second }), false},
+		{"distance within 5%", dedupBase, with(func(i *InfoMessage) { i.Distance = 10.52 }), true},
+		{"distance over 5%", dedupBase, with(func(i *InfoMessage) { i.Distance = 10.53 }), false},
+		{"short distance within 50 m", short, with(func(i *InfoMessage) { i.Distance = 0.54 }), true},
+		{"short distance over 50 m", short, with(func(i *InfoMessage) { i.Distance = 0.56 }), false},
+		{"other offset, same instant", dedupBase, with(func(i *InfoMessage) { i.StartedAt = i.StartedAt.UTC() }), true},
+		{"other offset, same wall clock", dedupBase, with(func(i *InfoMessage) {
+			i.StartedAt = time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+		}), false},
+		{"other type", dedupBase, with(func(i *InfoMessage) { i.TrainingType = "Ходьба" }), false},
+		{"no start", dedupBase, with(func(i *InfoMessage) { i.StartedAt = time.Time{} }), false},
+		{"both without start", with(func(i *InfoMessage) { i.StartedAt = time.Time{} }), with(func(i *InfoMessage) { i.StartedAt = time.Time{} }), false},
+	}
+	for _, tt := range tests {
+		if got := IsDuplicate(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: IsDuplicate() = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := IsDuplicate(tt.b, tt.a); got != tt.want {
+			t.Errorf("%s: IsDuplicate() with swapped arguments = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	same := dedupBase
+	same.StartedAt = same.StartedAt.UTC().Add(30 * time.Second)
+	same.Duration += 20 * time.Second
+	same.Distance = 10.04
+	if got, want := Fingerprint(same), Fingerprint(dedupBase); got != want {
+		t.Errorf("Fingerprint() = %q, want %q for the same workout in UTC", got, want)
+	}
+	if want := "Бег|2026-05-01T04:00:00Z|30|10.0"; Fingerprint(dedupBase) != want {
+		t.Errorf("Fingerprint() = %q, want %q", Fingerprint(dedupBase), want)
+	}
+
+	other := dedupBase
+	other.Distance = 10.2
+	if Fingerprint(other) == Fingerprint(dedupBase) {
+		t.Errorf("Fingerprint() = %q for 200 m longer workout", Fingerprint(other))
+	}
+}
+
+func TestDeduplicator(t *testing.T) {
+	watch := dedupBase
+	phone := dedupBase
+	phone.StartedAt = phone.StartedAt.UTC().Add(time.Minute)
+	phone.Distance = 10.1
+	evening := dedupBase
+	evening.StartedAt = evening.StartedAt.Add(11 * time.Hour)
+	undated := dedupBase
+	undated.StartedAt = time.Time{}
+
+	var d Deduplicator
+	for i, tt := range []struct {
+		info InfoMessage
+		want bool
+	}{
+		{watch, true},
+		{phone, false},
+		{evening, true},
+		{undated, true},
+		{undated, true},
+	} {
+		if got := d.AddIfNew(tt.info); got != tt.want {
+			t.Errorf("AddIfNew(#%d) = %v, want %v", i, got, tt.want)
+		}
+	}
+	if added := d.Added(); len(added) != 4 || added[0] != watch || added[1] != evening {
+		t.Errorf("Added() = %+v, want watch, evening and two undated workouts", added)
+	}
+	if skipped := d.Skipped(); len(skipped) != 1 || skipped[0] != phone {
+		t.Errorf("Skipped() = %+v, want the phone workout", skipped)
+	}
+}
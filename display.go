@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DisplayFormat настройки вывода числовых значений InfoMessage.
+// Точность -1 означает минимальное количество знаков, при котором значение читается без потерь.
+type DisplayFormat struct {
+	DurationPrecision int    // знаков после запятой в длительности в минутах
+	DistancePrecision int    // знаков после запятой в дистанции в км
+	SpeedPrecision    int    // знаков после запятой в скорости в км/ч
+	CaloriesPrecision int    // знаков после запятой в килокалориях
+	FuelingPrecision  int    // знаков после запятой в потере жидкости и углеводах
+	DecimalSeparator  string // разделитель целой и дробной части, пустое значение - точка
+}
+
+// DefaultDisplayFormat формат, которым пользуется InfoMessage.String().
+var DefaultDisplayFormat = DisplayFormat{
+	DurationPrecision: -1,
+	DistancePrecision: 2,
+	SpeedPrecision:    2,
+	CaloriesPrecision: 2,
+	FuelingPrecision:  0,
+	DecimalSeparator:  ".",
+}
+
+// LocaleDecimalSeparators разделители дробной части для языков.
+var LocaleDecimalSeparators = map[string]string{
+	"en": ".",
+	"ru": ",",
+	"de": ",",
+	"fr": ",",
+}
+
+// DisplayFormatForLocale возвращает DefaultDisplayFormat с разделителем дробной части для языка locale,
+// например "ru" или "ru-RU". Для неизвестного языка возвращается DefaultDisplayFormat.
+func DisplayFormatForLocale(locale string) DisplayFormat {
+	f := DefaultDisplayFormat
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	if sep, ok := LocaleDecimalSeparators[lang]; ok {
+		f.DecimalSeparator = sep
+	}
+	return f
+}
+
+// infoBufPool буферы для Format, чтобы вывод информации о тренировке не выделял память на промежуточные строки.
+var infoBufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// Number форматирует v с precision знаками после запятой.
+// NaN, бесконечности и отрицательные значения выводятся как 0, так как в информации о тренировке
+// они появляются только из-за некорректных входных данных.
+func (f DisplayFormat) Number(v float64, precision int) string {
+	return string(f.AppendNumber(nil, v, precision))
+}
+
+// AppendNumber добавляет к dst число v так же, как его выводит Number, и возвращает расширенный срез.
+func (f DisplayFormat) AppendNumber(dst []byte, v float64, precision int) []byte {
+	v = displayValue(v)
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, v, 'f', precision, 64)
+	sep := f.DecimalSeparator
+	if sep == "" || sep == "." {
+		return dst
+	}
+	dot := bytes.IndexByte(dst[start:], '.')
+	if dot < 0 {
+		return dst
+	}
+	dot += start
+	// раздвигаем дробную часть под разделитель длиннее одного байта, например U+066B
+	tail := len(dst) - dot - 1
+	dst = append(dst, sep[1:]...)
+	copy(dst[dot+len(sep):], dst[dot+1:dot+1+tail])
+	copy(dst[dot:], sep)
+	return dst
+}
+
+// Round возвращает v, округленное до precision знаков после запятой так же, как его выводит Number,
+// для форматов, где число записывается без разделителя, например JSON.
+func (f DisplayFormat) Round(v float64, precision int) float64 {
+	v = displayValue(v)
+	if precision < 0 {
+		return v
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'f', precision, 64), 64)
+	return rounded
+}
+
+// displayValue заменяет на 0 значения, которые нельзя показать: NaN, бесконечности, отрицательные и -0.
+func displayValue(v float64) float64 {
+	if !(v > 0) || math.IsInf(v, 1) {
+		return 0
+	}
+	return v
+}
+
+// AppendInfo добавляет к dst информацию о проведенной тренировке так же, как ее выводит Format,
+// и возвращает расширенный срез. Если в dst хватает места, память не выделяется.
+func (f DisplayFormat) AppendInfo(dst []byte, i InfoMessage) []byte {
+	dst = append(dst, "Тип тренировки: "...)
+	dst = append(dst, i.TrainingType...)
+	dst = append(dst, "\nДлительность: "...)
+	dst = f.AppendNumber(dst, i.Duration.Minutes(), f.DurationPrecision)
+	dst = append(dst, " мин\nДистанция: "...)
+	dst = f.AppendNumber(dst, i.Distance, f.DistancePrecision)
+	dst = append(dst, " км.\nСр. скорость: "...)
+	dst = f.AppendNumber(dst, i.Speed, f.SpeedPrecision)
+	dst = append(dst, " км/ч\nПотрачено ккал: "...)
+	dst = f.AppendNumber(dst, i.Calories, f.CaloriesPrecision)
+	dst = append(dst, '\n')
+	if !i.Fueling.IsZero() {
+		dst = append(dst, "Потеря жидкости: "...)
+		dst = f.AppendNumber(dst, i.Fueling.FluidLoss, f.FuelingPrecision)
+		dst = append(dst, " мл\nУглеводы: "...)
+		dst = f.AppendNumber(dst, i.Fueling.Carbs, f.FuelingPrecision)
+		dst = append(dst, " г\n"...)
+	}
+	if i.Estimated != 0 {
+		dst = append(dst, "Оценено по профилю: "...)
+		dst = i.Estimated.appendTitles(dst)
+		dst = append(dst, '\n')
+	}
+	return dst
+}
+
+// appendSegments добавляет к dst информацию по этапам составной тренировки, которая выводится после итога.
+// Каждый этап отделяется пустой строкой и начинается с заголовка с номером этапа.
+func (f DisplayFormat) appendSegments(dst []byte, segments []InfoMessage) []byte {
+	for i, segment := range segments {
+		dst = append(dst, "\nЭтап "...)
+		dst = strconv.AppendInt(dst, int64(i+1), 10)
+		dst = append(dst, ":\n"...)
+		dst = f.AppendInfo(dst, segment)
+	}
+	return dst
+}
+
+// AppendInfo добавляет к dst информацию о проведенной тренировке в формате DefaultDisplayFormat.
+func AppendInfo(dst []byte, i InfoMessage) []byte {
+	return DefaultDisplayFormat.AppendInfo(dst, i)
+}
+
+// Format возвращает строку с информацией о проведенной тренировке.
+func (f DisplayFormat) Format(i InfoMessage) string {
+	bp := infoBufPool.Get().(*[]byte)
+	b := f.AppendInfo((*bp)[:0], i)
+	s := string(b)
+	*bp = b
+	infoBufPool.Put(bp)
+	return s
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// benchInfo информация о тренировке Бег из main.
+var benchInfo = InfoMessage{
+	TrainingType: "Бег",
+	Duration:     30 * time.Minute,
+	Distance:     3.25,
+	Speed:        6.5,
+	Calories:     302.9145,
+}
+
+// benchBatch количество тренировок в одной итерации пакетных бенчмарков.
+const benchBatch = 1_000_000
+
+func TestAppendInfoMatchesString(t *testing.T) {
+	if got, want := string(AppendInfo(nil, benchInfo)), benchInfo.String(); got != want {
+		t.Errorf("AppendInfo() = %q, String() = %q", got, want)
+	}
+	buf := make([]byte, 0, 256)
+	if allocs := testing.AllocsPerRun(100, func() { buf = AppendInfo(buf[:0], benchInfo) }); allocs != 0 {
+		t.Errorf("AppendInfo() into a large enough buffer allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkAppendInfo(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 256)
+	for i := 0; i < b.N; i++ {
+		buf = AppendInfo(buf[:0], benchInfo)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = benchInfo.String()
+	}
+}
+
+// BenchmarkSprintf форматирование через fmt.Sprintf, как InfoMessage.String() работал до AppendInfo,
+// для сравнения с BenchmarkString и BenchmarkAppendInfo.
+func BenchmarkSprintf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("Тип тренировки: %s\nДлительность: %v мин\nДистанция: %.2f км.\nСр. скорость: %.2f км/ч\nПотрачено ккал: %.2f\n",
+			benchInfo.TrainingType, benchInfo.Duration.Minutes(), benchInfo.Distance, benchInfo.Speed, benchInfo.Calories)
+	}
+}
+
+// BenchmarkBatch расчет и вывод 1M тренировок за итерацию: через общий буфер AppendInfo и через String.
+func BenchmarkBatch(b *testing.B) {
+	running := Running{Training: Training{TrainingType: "Бег", Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}}
+	b.Run("AppendInfo", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 256)
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < benchBatch; j++ {
+				buf = AppendInfo(buf[:0], running.TrainingInfo())
+				io.Discard.Write(buf)
+			}
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < benchBatch; j++ {
+				io.WriteString(io.Discard, running.TrainingInfo().String())
+			}
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Surface покрытие, по которому проходит тренировка.
+type Surface string
+
+// Покрытия, для которых известна поправка калорий.
+const (
+	SurfaceRoad  Surface = "road"  // асфальт, покрытие по умолчанию
+	SurfaceTrack Surface = "track" // стадион
+	SurfaceGrass Surface = "grass" // трава
+	SurfaceTrail Surface = "trail" // грунтовая тропа
+	SurfaceSand  Surface = "sand"  // песок
+)
+
+// SurfaceCaloriesMultiplier множитель калорий для покрытия относительно асфальта.
+var SurfaceCaloriesMultiplier = map[Surface]float64{
+	SurfaceRoad:  1,
+	SurfaceTrack: 1,
+	SurfaceGrass: 1.07,
+	SurfaceTrail: 1.1,
+	SurfaceSand:  1.6,
+}
+
+// Константы для поправки калорий на погоду.
+const (
+	EnvComfortMinTemperature = 10    // нижняя граница температуры в °C, при которой поправки нет
+	EnvComfortMaxTemperature = 25    // верхняя граница температуры в °C, при которой поправки нет
+	EnvColdPerDegree         = 0.005 // прирост калорий на каждый °C ниже комфортной границы
+	EnvHeatPerDegree         = 0.01  // прирост калорий на каждый °C выше комфортной границы
+	EnvHeadwindPerMs         = 0.01  // прирост калорий на каждый м/с встречного ветра
+	EnvTailwindPerMs         = 0.005 // снижение калорий на каждый м/с попутного ветра
+	EnvMinWindMultiplier     = 0.9   // наименьший множитель калорий от попутного ветра
+	EnvMinTemperature        = -60   // наименьшая допустимая температура в °C
+	EnvMaxTemperature        = 60    // наибольшая допустимая температура в °C
+	EnvMaxWind               = 50    // наибольшая допустимая скорость ветра в м/с
+)
+
+// Environment условия, в которых проходила тренировка на улице.
+type Environment struct {
+	Temperature float64 // температура воздуха в °C, по ней также оценивается потеря жидкости
+	Surface     Surface // покрытие, пустое значение - асфальт
+	Wind        float64 // скорость ветра в м/с, положительная - встречный, отрицательная - попутный
+}
+
+// Validate проверяет, что температура и ветер не NaN и лежат в допустимых границах, а покрытие известно.
+// nil условия допустимы и означают тренировку без поправки.
+func (e *Environment) Validate() error {
+	if e == nil {
+		return nil
+	}
+	if !inRange(e.Temperature, EnvMinTemperature, EnvMaxTemperature) {
+		return fmt.Errorf("температура должна быть от %d до %d °C, получено %v", EnvMinTemperature, EnvMaxTemperature, e.Temperature)
+	}
+	if !inRange(e.Wind, -EnvMaxWind, EnvMaxWind) {
+		return fmt.Errorf("скорость ветра должна быть от %d до %d м/с, получено %v", -EnvMaxWind, EnvMaxWind, e.Wind)
+	}
+	if _, ok := SurfaceCaloriesMultiplier[e.Surface]; e.Surface != "" && !ok {
+		return fmt.Errorf("неизвестное покрытие %q", e.Surface)
+	}
+	return nil
+}
+
+// multiplier возвращает поправочный множитель калорий для условий тренировки.
+// Для nil условий поправки нет.
+// Формула расчета:
+// множитель_покрытия * множитель_температуры * множитель_ветра
+func (e *Environment) multiplier() float64 {
+	if e == nil {
+		return 1
+	}
+
+	surface := 1.0
+	if m, ok := SurfaceCaloriesMultiplier[e.Surface]; ok {
+		surface = m
+	}
+
+	temperature := 1.0
+	switch {
+	case e.Temperature < EnvComfortMinTemperature:
+		temperature += (EnvComfortMinTemperature - e.Temperature) * EnvColdPerDegree
+	case e.Temperature > EnvComfortMaxTemperature:
+		temperature += (e.Temperature - EnvComfortMaxTemperature) * EnvHeatPerDegree
+	}
+
+	wind := 1.0
+	if e.Wind > 0 {
+		wind += e.Wind * EnvHeadwindPerMs
+	} else {
+		wind = math.Max(EnvMinWindMultiplier, wind+e.Wind*EnvTailwindPerMs)
+	}
+
+	return surface * temperature * wind
+}
+
+// fueling возвращает оценку потерь жидкости и углеводов при температуре воздуха тренировки.
+// Для nil условий температура неизвестна, и оценка не проводится.
+func (e *Environment) fueling(info InfoMessage) Fueling {
+	if e == nil {
+		return Fueling{}
+	}
+	return EstimateFueling(info, e.Temperature)
+}
@@ -0,0 +1,112 @@
+package main
+
+import "strings"
+
+// Константы для оценки недостающих данных тренировки.
+const (
+	StepLengthHeightRatio = 0.414 // отношение длины шага к росту
+)
+
+// EstimatedFields набор полей тренировки, которые оценены, а не измерены.
+// Это битовая маска, а не срез, чтобы Training и InfoMessage оставались сравнимыми через ==.
+type EstimatedFields uint8
+
+// Поля, которые может оценить WithEstimates.
+const (
+	EstimatedWeight  EstimatedFields = 1 << iota // вес пользователя
+	EstimatedHeight                              // рост пользователя
+	EstimatedLenStep                             // длина шага или гребка
+)
+
+// estimatedFieldNames названия полей в порядке битов EstimatedFields.
+var estimatedFieldNames = [...]string{"weight", "height", "len_step"}
+
+// estimatedFieldTitles названия полей для текстового вывода в порядке битов EstimatedFields.
+var estimatedFieldTitles = [...]string{"вес", "рост", "длина шага"}
+
+// Has сообщает, что в наборе есть все поля f.
+func (e EstimatedFields) Has(f EstimatedFields) bool {
+	return e&f == f
+}
+
+// Names возвращает названия полей набора, например "weight" и "len_step", или nil для пустого набора.
+func (e EstimatedFields) Names() []string {
+	var names []string
+	for i, name := range estimatedFieldNames {
+		if e.Has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// String возвращает названия полей набора через запятую.
+func (e EstimatedFields) String() string {
+	return strings.Join(e.Names(), ",")
+}
+
+// appendTitles добавляет к dst названия полей набора для текстового вывода через запятую.
+func (e EstimatedFields) appendTitles(dst []byte) []byte {
+	first := true
+	for i, title := range estimatedFieldTitles {
+		if !e.Has(1 << i) {
+			continue
+		}
+		if !first {
+			dst = append(dst, ", "...)
+		}
+		dst = append(dst, title...)
+		first = false
+	}
+	return dst
+}
+
+// WithEstimates возвращает копию тренировки, в которой недостающие данные заполнены по профилю:
+// вес и рост берутся из профиля, а длина шага оценивается как рост * 0.414.
+// Для плавания и гребли вместо длины шага берутся SwimmingLenStep и RowingLenStep.
+// Длина шага не заполняется, если дистанция задана иначе и шаг в расчетах не используется:
+// для бега по дорожке или с измеренной дистанцией и для гребли с Meters или Split.
+// Заполненные поля добавляются в Training.Estimated и попадают в InfoMessage.Estimated.
+// Тренировки других типов возвращаются без изменений.
+func WithEstimates(training CaloriesCalculator, p UserProfile) CaloriesCalculator {
+	switch t := training.(type) {
+	case Running:
+		lenStep := p.Height / CmInM * StepLengthHeightRatio
+		if t.measuredDistance() > 0 {
+			lenStep = 0
+		}
+		t.Training = t.Training.withEstimates(p, lenStep)
+		return t
+	case Walking:
+		if t.Height == 0 && p.Height > 0 {
+			t.Height = p.Height
+			t.Estimated |= EstimatedHeight
+		}
+		t.Training = t.Training.withEstimates(p, t.Height/CmInM*StepLengthHeightRatio)
+		return t
+	case Swimming:
+		t.Training = t.Training.withEstimates(p, SwimmingLenStep)
+		return t
+	case Rowing:
+		lenStep := float64(RowingLenStep)
+		if t.Meters > 0 || t.Split > 0 {
+			lenStep = 0
+		}
+		t.Training = t.Training.withEstimates(p, lenStep)
+		return t
+	}
+	return training
+}
+
+// withEstimates заполняет вес из профиля и длину шага значением lenStep, если они не заданы.
+func (t Training) withEstimates(p UserProfile, lenStep float64) Training {
+	if t.Weight == 0 && p.Weight > 0 {
+		t.Weight = p.Weight
+		t.Estimated |= EstimatedWeight
+	}
+	if t.LenStep == 0 && lenStep > 0 {
+		t.LenStep = lenStep
+		t.Estimated |= EstimatedLenStep
+	}
+	return t
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithEstimates(t *testing.T) {
+	walking := Walking{Training: Training{TrainingType: "Ходьба", Action: 10000, Duration: time.Hour}}
+	profile := UserProfile{Weight: 70, Height: 180}
+
+	got := WithEstimates(walking, profile).(Walking)
+	if got.Weight != 70 || got.Height != 180 || math.Abs(got.LenStep-1.8*StepLengthHeightRatio) > 1e-9 {
+		t.Errorf("WithEstimates() = %+v, want weight, height and step from profile", got)
+	}
+	want := EstimatedWeight | EstimatedHeight | EstimatedLenStep
+	if got.Estimated != want || got.TrainingInfo().Estimated != want {
+		t.Errorf("Estimated = %v, want %v", got.Estimated, want)
+	}
+	if walking.Estimated != 0 {
+		t.Errorf("WithEstimates() changed the original workout: %v", walking.Estimated)
+	}
+
+	data, err := ReadDataAs(got, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j struct {
+		Estimated []string `json:"estimated"`
+	}
+	if err := json.Unmarshal([]byte(data), &j); err != nil {
+		t.Fatal(err)
+	}
+	if names := []string{"weight", "height", "len_step"}; !reflect.DeepEqual(j.Estimated, names) {
+		t.Errorf("FormatJSON estimated = %v, want %v", j.Estimated, names)
+	}
+}
+
+func TestWorkoutsComparable(t *testing.T) {
+	// сравнение через == должно компилироваться для информации о тренировке и всех типов тренировок
+	running := Running{Training: Training{Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}}
+	if running != running || running.TrainingInfo() != running.TrainingInfo() {
+		t.Error("equal Running values compare unequal")
+	}
+	_ = Walking{} == Walking{}
+	_ = Swimming{} == Swimming{}
+	_ = Rowing{} == Rowing{}
+	_ = Training{} == Training{}
+}
+
+func TestEstimatedOutput(t *testing.T) {
+	profile := UserProfile{Weight: 70, Height: 180}
+	running := WithEstimates(Running{Training: Training{TrainingType: "Бег", Action: 5000, Duration: 30 * time.Minute}}, profile)
+
+	text := ReadData(running)
+	if !strings.HasSuffix(text, "Оценено по профилю: вес, длина шага\n") {
+		t.Errorf("ReadData() = %q, want estimated fields marker", text)
+	}
+	var sb strings.Builder
+	if err := WriteInfoTemplate(&sb, running, InfoTemplate("")); err != nil || sb.String() != text {
+		t.Errorf("WriteInfoTemplate() = %q, %v, want %q", sb.String(), err, text)
+	}
+	info := running.TrainingInfo()
+	buf := make([]byte, 0, 512)
+	if allocs := testing.AllocsPerRun(100, func() { buf = AppendInfo(buf[:0], info) }); allocs != 0 {
+		t.Errorf("AppendInfo() with estimated fields allocates %v times, want 0", allocs)
+	}
+
+	data, err := ReadDataAs(running, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csv.NewReader(strings.NewReader(data)).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record) != len(CSVHeader) || record[len(record)-1] != "weight,len_step" {
+		t.Errorf("FormatCSV = %q, want estimated column %q", record, "weight,len_step")
+	}
+
+	if text := ReadData(Running{Training: Training{TrainingType: "Бег", Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}}); strings.Contains(text, "Оценено") {
+		t.Errorf("ReadData() without estimates = %q, want no marker", text)
+	}
+}
+
+func TestWithEstimatesSkipsUnusedLenStep(t *testing.T) {
+	profile := UserProfile{Weight: 70, Height: 180}
+	base := Training{Action: 5000, Duration: 30 * time.Minute}
+	tests := []struct {
+		name     string
+		training CaloriesCalculator
+		want     EstimatedFields
+	}{
+		{"running by steps", Running{Training: base}, EstimatedWeight | EstimatedLenStep},
+		{"running on treadmill", Running{Training: base, TreadmillDistance: 5}, EstimatedWeight},
+		{"running measured", Running{Training: base, MeasuredDistance: 5}, EstimatedWeight},
+		{"rowing by strokes", Rowing{Training: base}, EstimatedWeight | EstimatedLenStep},
+		{"rowing meters", Rowing{Training: base, Meters: 6000}, EstimatedWeight},
+		{"rowing split", Rowing{Training: base, Split: 2 * time.Minute}, EstimatedWeight},
+	}
+	for _, tt := range tests {
+		if got := WithEstimates(tt.training, profile).TrainingInfo().Estimated; got != tt.want {
+			t.Errorf("%s: Estimated = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// Константы для оценки потерь жидкости и потребности в углеводах.
+const (
+	FuelingMetabolicHeatShare  = 0.8  // доля потраченной энергии, которая уходит в тепло
+	FuelingSweatLatentHeat     = 0.58 // ккал, которые отводит испарение 1 мл пота
+	FuelingMinEvaporativeShare = 0.3  // доля тепла, отводимая потом при температуре FuelingCoolTemperature и ниже
+	FuelingCoolTemperature     = 10   // температура воздуха в °C, ниже которой доля пота не уменьшается
+	FuelingHotTemperature      = 35   // температура воздуха в °C, при которой все тепло отводится потом
+	FuelingShortCarbsPerHour   = 45   // г углеводов в час для тренировок от 1 до 2.5 часов
+	FuelingLongCarbsPerHour    = 75   // г углеводов в час для тренировок дольше 2.5 часов
+)
+
+// Fueling оценка потерь жидкости и рекомендуемого количества углеводов на тренировке.
+type Fueling struct {
+	FluidLoss float64 // потеря жидкости в мл
+	Carbs     float64 // рекомендуемое количество углеводов в г
+}
+
+// IsZero сообщает, что оценка не проводилась.
+func (f Fueling) IsZero() bool {
+	return f.FluidLoss == 0 && f.Carbs == 0
+}
+
+// EstimateFueling оценивает потерю жидкости и потребность в углеводах по информации о тренировке
+// и температуре воздуха в °C. Интенсивность тренировки учитывается через потраченные калории:
+// потеря_жидкости_в_мл = ккал * доля_тепла / теплота_испарения_пота * доля_тепла_отводимая_потом(температура)
+// Углеводы рекомендуются только для тренировок дольше часа, по нормам г/ч для длительности.
+func EstimateFueling(info InfoMessage, temperature float64) Fueling {
+	return Fueling{
+		FluidLoss: info.Calories * FuelingMetabolicHeatShare / FuelingSweatLatentHeat * evaporativeShare(temperature),
+		Carbs:     carbsPerHour(info.Duration) * info.Duration.Hours(),
+	}
+}
+
+// evaporativeShare возвращает долю тепла, которая отводится испарением пота при заданной температуре воздуха.
+// Доля растет линейно от FuelingMinEvaporativeShare до 1 между FuelingCoolTemperature и FuelingHotTemperature.
+func evaporativeShare(temperature float64) float64 {
+	share := FuelingMinEvaporativeShare +
+		(1-FuelingMinEvaporativeShare)*(temperature-FuelingCoolTemperature)/(FuelingHotTemperature-FuelingCoolTemperature)
+	return math.Max(FuelingMinEvaporativeShare, math.Min(1, share))
+}
+
+// carbsPerHour возвращает рекомендуемое количество углеводов в г/ч для тренировки заданной продолжительности.
+func carbsPerHour(duration time.Duration) float64 {
+	switch {
+	case duration < time.Hour:
+		return 0
+	case duration <= 150*time.Minute:
+		return FuelingShortCarbsPerHour
+	default:
+		return FuelingLongCarbsPerHour
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/Yandex-Practicum/go-1fl-homework-sprint5
 
-go 1.20
+go 1.21
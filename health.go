@@ -0,0 +1,374 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Константы для импорта из Apple Health и Google Fit.
+const (
+	appleHealthTimeLayout = "2006-01-02 15:04:05 -0700" // формат времени в export.xml
+	appleStepCountType    = "HKQuantityTypeIdentifierStepCount"
+	MetersInMile          = 1609.344 // количество метров в одной миле
+	MetersInYard          = 0.9144   // количество метров в одном ярде
+	DefaultPoolLength     = 25       // длина бассейна в м, если она не указана в импорте
+)
+
+// HealthImportOptions данные пользователя, которых нет в выгрузке, но которые нужны для расчета калорий.
+type HealthImportOptions struct {
+	Weight     float64 // вес пользователя в кг
+	Height     float64 // рост пользователя в см, нужен для тренировок Ходьба
+	PoolLength int     // длина бассейна в м, 0 - DefaultPoolLength
+}
+
+// HealthImport результат импорта из Apple Health или Google Fit.
+type HealthImport struct {
+	Workouts []CaloriesCalculator // тренировки в порядке времени начала
+	Skipped  []string             // типы тренировок, которые не поддерживаются и были пропущены
+}
+
+// healthWorkout тренировка из выгрузки до преобразования в типы пакета.
+type healthWorkout struct {
+	kind     string // "run", "walk" или "swim"
+	start    time.Time
+	duration time.Duration
+	distance float64 // дистанция в м
+	steps    float64
+}
+
+// appleWorkout элемент Workout из export.xml.
+type appleWorkout struct {
+	ActivityType      string  `xml:"workoutActivityType,attr"`
+	Duration          float64 `xml:"duration,attr"`
+	DurationUnit      string  `xml:"durationUnit,attr"`
+	TotalDistance     float64 `xml:"totalDistance,attr"`
+	TotalDistanceUnit string  `xml:"totalDistanceUnit,attr"`
+	StartDate         string  `xml:"startDate,attr"`
+	EndDate           string  `xml:"endDate,attr"`
+	Statistics        []struct {
+		Type string  `xml:"type,attr"`
+		Sum  float64 `xml:"sum,attr"`
+		Unit string  `xml:"unit,attr"`
+	} `xml:"WorkoutStatistics"`
+}
+
+// appleRecord элемент Record из export.xml.
+type appleRecord struct {
+	Type      string  `xml:"type,attr"`
+	Value     float64 `xml:"value,attr"`
+	StartDate string  `xml:"startDate,attr"`
+	EndDate   string  `xml:"endDate,attr"`
+}
+
+// stepInterval шаги за отрезок времени, который не перекрывается с другими отрезками.
+type stepInterval struct {
+	start, end time.Time
+	steps      float64
+}
+
+// appleWorkoutKinds соответствие типов тренировок Apple Health типам пакета.
+var appleWorkoutKinds = map[string]string{
+	"HKWorkoutActivityTypeRunning":  "run",
+	"HKWorkoutActivityTypeWalking":  "walk",
+	"HKWorkoutActivityTypeHiking":   "walk",
+	"HKWorkoutActivityTypeSwimming": "swim",
+}
+
+// ImportAppleHealth читает export.xml из выгрузки Apple Health. Файл разбирается потоково,
+// поэтому подходит и для многолетней истории. Шаги из записей StepCount, которые попадают
+// на время тренировки, добавляются к ней, а остальные собираются в тренировку Ходьба на каждый
+// календарный день. Записи разных источников (iPhone, Apple Watch) об одних и тех же шагах
+// объединяются, см. mergeStepRecords.
+func ImportAppleHealth(r io.Reader, opts HealthImportOptions) (HealthImport, error) {
+	var result HealthImport
+	var workouts []healthWorkout
+	var steps []appleRecord
+
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return HealthImport{}, fmt.Errorf("разбор выгрузки Apple Health: %w", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+		case "Workout":
+			var aw appleWorkout
+			if err := d.DecodeElement(&aw, &se); err != nil {
+				return HealthImport{}, fmt.Errorf("разбор тренировки Apple Health: %w", err)
+			}
+			kind, ok := appleWorkoutKinds[aw.ActivityType]
+			if !ok {
+				result.Skipped = append(result.Skipped, aw.ActivityType)
+				continue
+			}
+			w, err := aw.workout(kind)
+			if err != nil {
+				return HealthImport{}, err
+			}
+			workouts = append(workouts, w)
+		case "Record":
+			var rec appleRecord
+			if err := d.DecodeElement(&rec, &se); err != nil {
+				return HealthImport{}, fmt.Errorf("разбор записи Apple Health: %w", err)
+			}
+			if rec.Type == appleStepCountType {
+				steps = append(steps, rec)
+			}
+		}
+	}
+
+	intervals, err := mergeStepRecords(steps)
+	if err != nil {
+		return HealthImport{}, err
+	}
+	// день хранится строкой, а не time.Time: time.Parse создает свой *time.Location для каждого
+	// смещения не на целое число часов, и одинаковые дни дают разные ключи
+	daily := make(map[string]*healthWorkout)
+	for _, in := range intervals {
+		if w := findHealthWorkout(workouts, in.start); w != nil {
+			w.steps += in.steps
+			continue
+		}
+		day := in.start.Format(time.DateOnly)
+		w, ok := daily[day]
+		if !ok {
+			w = &healthWorkout{kind: "walk", start: in.start}
+			daily[day] = w
+		}
+		w.duration += in.end.Sub(in.start)
+		w.steps += in.steps
+	}
+	for _, w := range daily {
+		workouts = append(workouts, *w)
+	}
+
+	result.Workouts = healthWorkouts(workouts, opts)
+	return result, nil
+}
+
+// mergeStepRecords возвращает неперекрывающиеся отрезки с шагами из записей StepCount.
+// Apple Health хранит шаги отдельно для каждого источника, поэтому iPhone и Apple Watch
+// записывают одни и те же шаги дважды. Записи упорядочиваются по времени начала, и из каждой
+// берется только часть, которая не перекрыта уже учтенными записями; шаги этой части
+// считаются пропорционально ее длительности.
+func mergeStepRecords(records []appleRecord) ([]stepInterval, error) {
+	intervals := make([]stepInterval, 0, len(records))
+	for _, rec := range records {
+		start, err := time.Parse(appleHealthTimeLayout, rec.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("время начала записи Apple Health: %w", err)
+		}
+		end, err := time.Parse(appleHealthTimeLayout, rec.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("время окончания записи Apple Health: %w", err)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("запись Apple Health заканчивается раньше, чем начинается: %s - %s", rec.StartDate, rec.EndDate)
+		}
+		intervals = append(intervals, stepInterval{start: start, end: end, steps: rec.Value})
+	}
+	sort.SliceStable(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	merged := intervals[:0]
+	var covered time.Time
+	for _, in := range intervals {
+		if !in.end.After(covered) {
+			continue
+		}
+		if in.start.Before(covered) {
+			in.steps *= float64(in.end.Sub(covered)) / float64(in.end.Sub(in.start))
+			in.start = covered
+		}
+		merged = append(merged, in)
+		covered = in.end
+	}
+	return merged, nil
+}
+
+// workout преобразует тренировку Apple Health в healthWorkout.
+// Отрицательная продолжительность и окончание раньше начала считаются ошибкой, как и для записей шагов.
+func (aw appleWorkout) workout(kind string) (healthWorkout, error) {
+	start, err := time.Parse(appleHealthTimeLayout, aw.StartDate)
+	if err != nil {
+		return healthWorkout{}, fmt.Errorf("время начала тренировки Apple Health: %w", err)
+	}
+	end := start
+	if aw.EndDate != "" {
+		if end, err = time.Parse(appleHealthTimeLayout, aw.EndDate); err != nil {
+			return healthWorkout{}, fmt.Errorf("время окончания тренировки Apple Health: %w", err)
+		}
+		if end.Before(start) {
+			return healthWorkout{}, fmt.Errorf("тренировка Apple Health заканчивается раньше, чем начинается: %s - %s", aw.StartDate, aw.EndDate)
+		}
+	}
+	if !isNonNegative(aw.Duration) {
+		return healthWorkout{}, fmt.Errorf("продолжительность тренировки Apple Health должна быть неотрицательным числом, получено %v", aw.Duration)
+	}
+	w := healthWorkout{kind: kind, start: start}
+
+	switch aw.DurationUnit {
+	case "min", "":
+		w.duration = time.Duration(aw.Duration * float64(time.Minute))
+	case "s":
+		w.duration = time.Duration(aw.Duration * float64(time.Second))
+	case "hr":
+		w.duration = time.Duration(aw.Duration * float64(time.Hour))
+	default:
+		return healthWorkout{}, fmt.Errorf("неизвестная единица продолжительности %q", aw.DurationUnit)
+	}
+	if w.duration == 0 {
+		w.duration = end.Sub(start)
+	}
+
+	distance, unit := aw.TotalDistance, aw.TotalDistanceUnit
+	for _, st := range aw.Statistics {
+		if distance == 0 && strings.HasPrefix(st.Type, "HKQuantityTypeIdentifierDistance") {
+			distance, unit = st.Sum, st.Unit
+		}
+	}
+	if w.distance, err = healthMeters(distance, unit); err != nil {
+		return healthWorkout{}, err
+	}
+	return w, nil
+}
+
+// healthMeters переводит дистанцию в метры.
+func healthMeters(distance float64, unit string) (float64, error) {
+	switch unit {
+	case "m", "":
+		return distance, nil
+	case "km":
+		return distance * MInKm, nil
+	case "mi":
+		return distance * MetersInMile, nil
+	case "yd":
+		return distance * MetersInYard, nil
+	}
+	return 0, fmt.Errorf("неизвестная единица дистанции %q", unit)
+}
+
+// findHealthWorkout возвращает тренировку, во время которой было t, или nil.
+func findHealthWorkout(workouts []healthWorkout, t time.Time) *healthWorkout {
+	for i := range workouts {
+		w := &workouts[i]
+		if !t.Before(w.start) && t.Before(w.start.Add(w.duration)) {
+			return w
+		}
+	}
+	return nil
+}
+
+// tcxDatabase корень файла TCX.
+type tcxDatabase struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		ID    string `xml:"Id"`
+		Notes string `xml:"Notes"`
+		Laps  []struct {
+			StartTime        string  `xml:"StartTime,attr"`
+			TotalTimeSeconds float64 `xml:"TotalTimeSeconds"`
+			DistanceMeters   float64 `xml:"DistanceMeters"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+// ImportGoogleFit читает файл TCX из папки Fit/Activities выгрузки Google Takeout.
+// Google Fit записывает ходьбу и плавание как Sport="Other" и указывает вид активности в Notes.
+func ImportGoogleFit(r io.Reader, opts HealthImportOptions) (HealthImport, error) {
+	var db tcxDatabase
+	if err := xml.NewDecoder(r).Decode(&db); err != nil {
+		return HealthImport{}, fmt.Errorf("разбор файла Google Fit: %w", err)
+	}
+
+	var result HealthImport
+	var workouts []healthWorkout
+	for _, a := range db.Activities {
+		kind := googleFitKind(a.Sport, a.Notes)
+		if kind == "" {
+			result.Skipped = append(result.Skipped, strings.TrimSpace(a.Sport+" "+a.Notes))
+			continue
+		}
+
+		start, err := time.Parse(time.RFC3339, a.ID)
+		if err != nil {
+			return HealthImport{}, fmt.Errorf("время начала тренировки Google Fit: %w", err)
+		}
+		w := healthWorkout{kind: kind, start: start}
+		for _, lap := range a.Laps {
+			w.duration += time.Duration(lap.TotalTimeSeconds * float64(time.Second))
+			w.distance += lap.DistanceMeters
+		}
+		workouts = append(workouts, w)
+	}
+
+	result.Workouts = healthWorkouts(workouts, opts)
+	return result, nil
+}
+
+// googleFitKind возвращает вид тренировки для активности TCX или пустую строку, если вид не поддерживается.
+func googleFitKind(sport, notes string) string {
+	if sport == "Running" {
+		return "run"
+	}
+	notes = strings.ToLower(notes)
+	switch {
+	case strings.Contains(notes, "walk"), strings.Contains(notes, "hik"):
+		return "walk"
+	case strings.Contains(notes, "swim"):
+		return "swim"
+	case strings.Contains(notes, "run"):
+		return "run"
+	}
+	return ""
+}
+
+// healthWorkouts преобразует тренировки из выгрузки в типы пакета, упорядочивая их по времени начала.
+// Если в выгрузке есть дистанция, она берется как есть: для бега через MeasuredDistance,
+// для ходьбы через длину шага, для плавания через количество пересечений бассейна.
+func healthWorkouts(workouts []healthWorkout, opts HealthImportOptions) []CaloriesCalculator {
+	sort.SliceStable(workouts, func(i, j int) bool { return workouts[i].start.Before(workouts[j].start) })
+
+	pool := opts.PoolLength
+	if pool <= 0 {
+		pool = DefaultPoolLength
+	}
+
+	result := make([]CaloriesCalculator, 0, len(workouts))
+	for _, w := range workouts {
+		t := Training{Action: int(math.Round(w.steps)), LenStep: LenStep, Duration: w.duration, Weight: opts.Weight, StartedAt: w.start}
+		switch w.kind {
+		case "run":
+			t.TrainingType = "Бег"
+			result = append(result, Running{Training: t, MeasuredDistance: w.distance / MInKm})
+		case "walk":
+			t.TrainingType = WalkingTrainingType
+			switch {
+			case w.distance > 0 && w.steps > 0:
+				t.LenStep = w.distance / w.steps
+			case w.distance > 0:
+				t.Action = int(math.Round(w.distance / LenStep))
+			}
+			result = append(result, Walking{Training: t, Height: opts.Height})
+		case "swim":
+			t.TrainingType = "Плавание"
+			t.LenStep = SwimmingLenStep
+			t.Action = int(math.Round(w.distance / SwimmingLenStep))
+			result = append(result, Swimming{Training: t, LengthPool: pool, CountPool: int(math.Round(w.distance / float64(pool)))})
+		}
+	}
+	return result
+}
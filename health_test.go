@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportAppleHealth(t *testing.T) {
+	f, err := os.Open("testdata/apple_health_export.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := ImportAppleHealth(f, HealthImportOptions{Weight: 75, Height: 180})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Skipped) != 1 || got.Skipped[0] != "HKWorkoutActivityTypeCycling" {
+		t.Errorf("Skipped = %v, want the cycling workout", got.Skipped)
+	}
+	if len(got.Workouts) != 2 {
+		t.Fatalf("Workouts = %+v, want daily walk and run", got.Workouts)
+	}
+
+	// iPhone и Apple Watch записали утренние шаги дважды: 3000 за 8:00-8:30 от первой записи,
+	// половина 1500 за 8:30-8:45 и половина 2000 за 8:45-9:00
+	walk, ok := got.Workouts[0].(Walking)
+	if !ok {
+		t.Fatalf("Workouts[0] = %T, want Walking", got.Workouts[0])
+	}
+	if walk.Action != 4750 || walk.Duration != time.Hour {
+		t.Errorf("walk Action = %d, Duration = %v, want 4750 steps in 1h", walk.Action, walk.Duration)
+	}
+
+	run, ok := got.Workouts[1].(Running)
+	if !ok {
+		t.Fatalf("Workouts[1] = %T, want Running", got.Workouts[1])
+	}
+	if run.Action != 4800 || run.MeasuredDistance != 5 || run.TreadmillDistance != 0 {
+		t.Errorf("run = %+v, want 4800 steps and measured distance 5 km", run)
+	}
+	info := run.TrainingInfo()
+	if want := 5000.0 / 4800; math.Abs(info.StrideLength-want) > 1e-9 {
+		t.Errorf("StrideLength = %v, want %v", info.StrideLength, want)
+	}
+}
+
+func TestImportGoogleFit(t *testing.T) {
+	f, err := os.Open("testdata/google_fit.tcx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := ImportGoogleFit(f, HealthImportOptions{Weight: 75, Height: 180})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Skipped) != 1 || got.Skipped[0] != "Biking" {
+		t.Errorf("Skipped = %v, want Biking", got.Skipped)
+	}
+	if len(got.Workouts) != 2 {
+		t.Fatalf("Workouts = %+v, want walk and run", got.Workouts)
+	}
+
+	walk := got.Workouts[0].TrainingInfo()
+	if walk.TrainingType != WalkingTrainingType || math.Abs(walk.Distance-5.2) > 0.001 || walk.Duration != time.Hour {
+		t.Errorf("Workouts[0] = %+v, want 5.2 km walk in 1h", walk)
+	}
+	run := got.Workouts[1].TrainingInfo()
+	if run.Distance != 6 || run.Duration != 30*time.Minute {
+		t.Errorf("Workouts[1] = %+v, want 6 km run in 30m", run)
+	}
+}
+
+func TestImportAppleHealthHalfHourOffset(t *testing.T) {
+	f, err := os.Open("testdata/apple_health_export_ist.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// смещение +0530 не на целое число часов, но шаги одного дня все равно собираются в одну ходьбу
+	got, err := ImportAppleHealth(f, HealthImportOptions{Weight: 60, Height: 165})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Workouts) != 2 {
+		t.Fatalf("Workouts = %+v, want one walk per day", got.Workouts)
+	}
+	walk, ok := got.Workouts[0].(Walking)
+	if !ok {
+		t.Fatalf("Workouts[0] = %T, want Walking", got.Workouts[0])
+	}
+	if walk.Action != 6500 || walk.Duration != 75*time.Minute {
+		t.Errorf("walk Action = %d, Duration = %v, want 6500 steps in 1h15m", walk.Action, walk.Duration)
+	}
+	if next := got.Workouts[1].TrainingInfo(); next.Duration != 15*time.Minute {
+		t.Errorf("Workouts[1] Duration = %v, want 15m of the next day", next.Duration)
+	}
+}
+
+func TestImportAppleHealthRejectsNegativeWorkout(t *testing.T) {
+	const workout = `<HealthData><Workout workoutActivityType="HKWorkoutActivityTypeRunning" %s/></HealthData>`
+	for _, attrs := range []string{
+		`duration="-30" durationUnit="min" startDate="2026-05-01 18:00:00 +0300"`,
+		`duration="NaN" durationUnit="min" startDate="2026-05-01 18:00:00 +0300"`,
+		`duration="0" startDate="2026-05-01 18:00:00 +0300" endDate="2026-05-01 17:30:00 +0300"`,
+		`duration="30" durationUnit="min" startDate="2026-05-01 18:00:00 +0300" endDate="2026-05-01 17:30:00 +0300"`,
+	} {
+		got, err := ImportAppleHealth(strings.NewReader(fmt.Sprintf(workout, attrs)), HealthImportOptions{Weight: 75})
+		if err == nil {
+			t.Errorf("ImportAppleHealth(%s) = %+v, want error", attrs, got.Workouts)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsTimeLayout формат времени iCalendar в UTC.
+const icsTimeLayout = "20060102T150405Z"
+
+// icsMaxLineLen максимальная длина строки iCalendar в байтах без CRLF.
+const icsMaxLineLen = 75
+
+// icsEscaper экранирует текстовые значения iCalendar.
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// WriteICS записывает тренировки в w как календарь iCalendar (RFC 5545), по событию на тренировку.
+// В описание события попадает текст, который возвращает ReadData.
+// Тренировки без времени начала пропускаются, так как их нельзя поставить в календарь.
+func WriteICS(w io.Writer, trainings ...CaloriesCalculator) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(icsTimeLayout)
+
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//5sprint//fitness tracker//RU")
+	for _, training := range trainings {
+		info := readInfo(training)
+		if info.StartedAt.IsZero() {
+			continue
+		}
+		start := info.StartedAt.UTC()
+
+		writeICSLine(bw, "BEGIN:VEVENT")
+		writeICSLine(bw, "UID:"+icsUID(info))
+		writeICSLine(bw, "DTSTAMP:"+stamp)
+		writeICSLine(bw, "DTSTART:"+start.Format(icsTimeLayout))
+		writeICSLine(bw, "DTEND:"+start.Add(info.Duration).Format(icsTimeLayout))
+		writeICSLine(bw, "SUMMARY:"+icsEscaper.Replace(info.TrainingType))
+		writeICSLine(bw, "DESCRIPTION:"+icsEscaper.Replace(strings.TrimSuffix(info.String(), "\n")))
+		writeICSLine(bw, "END:VEVENT")
+	}
+	writeICSLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// icsUID возвращает идентификатор события, который не меняется при повторной выгрузке той же тренировки.
+func icsUID(info InfoMessage) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%d|%d", info.TrainingType, info.StartedAt.UnixNano(), info.Duration)
+	return fmt.Sprintf("%x@5sprint", h.Sum64())
+}
+
+// writeICSLine записывает строку iCalendar, перенося ее по icsMaxLineLen байт без разрыва символов UTF-8.
+// Ошибки записи накапливаются в bufio.Writer и возвращаются из Flush.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := icsMaxLineLen
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// строки продолжения начинаются с пробела, который входит в лимит
+		limit = icsMaxLineLen - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
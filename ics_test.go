@@ -0,0 +1,84 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// icsUIDPattern выделяет идентификаторы событий из календаря.
+var icsUIDPattern = regexp.MustCompile(`(?m)^UID:(.*)\r$`)
+
+func TestWriteICS(t *testing.T) {
+	started := time.Date(2026, 5, 1, 7, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	running := Running{Training: Training{
+		TrainingType: "Бег, интервалы; 5\\3\nутро",
+		Action:       5000,
+		LenStep:      LenStep,
+		Duration:     30 * time.Minute,
+		Weight:       85,
+		StartedAt:    started,
+	}}
+	evening := running
+	evening.StartedAt = started.Add(11 * time.Hour)
+	undated := running
+	undated.StartedAt = time.Time{}
+
+	var sb strings.Builder
+	if err := WriteICS(&sb, running, undated, evening); err != nil {
+		t.Fatal(err)
+	}
+	data := sb.String()
+
+	if !strings.HasSuffix(data, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar does not end with CRLF:\n%q", data)
+	}
+	lines := strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n")
+	for _, line := range lines {
+		if strings.ContainsAny(line, "\r\n") {
+			t.Errorf("line %q has a bare CR or LF", line)
+		}
+		if len(line) > icsMaxLineLen {
+			t.Errorf("line %q is %d octets, want at most %d", line, len(line), icsMaxLineLen)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %q splits a UTF-8 character", line)
+		}
+	}
+	if got := strings.Count(data, "BEGIN:VEVENT\r\n"); got != 2 {
+		t.Errorf("calendar has %d events, want 2 without the undated workout", got)
+	}
+
+	if !strings.Contains(data, "\r\n ") {
+		t.Errorf("calendar has no folded lines, want the long Cyrillic description folded:\n%s", data)
+	}
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+	if want := `SUMMARY:Бег\, интервалы\; 5\\3\nутро` + "\r\n"; !strings.Contains(unfolded, want) {
+		t.Errorf("calendar has no escaped summary %q:\n%s", want, unfolded)
+	}
+	if want := `DESCRIPTION:Тип тренировки: Бег\, интервалы\; 5\\3\nутро\nДлительность: 30 мин\n`; !strings.Contains(unfolded, want) {
+		t.Errorf("calendar has no escaped description %q:\n%s", want, unfolded)
+	}
+	if !strings.Contains(unfolded, "DTSTART:20260501T040000Z\r\nDTEND:20260501T043000Z\r\n") {
+		t.Errorf("calendar has no UTC start and end:\n%s", unfolded)
+	}
+
+	// повторная выгрузка дает те же UID, а разные тренировки - разные
+	sb.Reset()
+	if err := WriteICS(&sb, evening, running); err != nil {
+		t.Fatal(err)
+	}
+	first := icsUIDPattern.FindAllStringSubmatch(data, -1)
+	second := icsUIDPattern.FindAllStringSubmatch(sb.String(), -1)
+	if len(first) != 2 || len(second) != 2 {
+		t.Fatalf("UIDs = %v and %v, want two in each calendar", first, second)
+	}
+	if first[0][1] == first[1][1] {
+		t.Errorf("UID %q is the same for different workouts", first[0][1])
+	}
+	if first[0][1] != second[1][1] || first[1][1] != second[0][1] {
+		t.Errorf("UIDs = %v, then %v, want stable UIDs", first, second)
+	}
+}
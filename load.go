@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Константы для расчета тренировочной нагрузки.
+const (
+	TRIMPMultiplier = 0.64 // множитель TRIMP Банистера
+	TRIMPExponent   = 1.92 // показатель экспоненты TRIMP Банистера
+	LoadKcalPerUnit = 10   // ккал на единицу нагрузки для тренировок без пульса
+	AcuteLoadDays   = 7    // окно острой нагрузки в днях
+	ChronicLoadDays = 28   // окно хронической нагрузки в днях
+	HighLoadRatio   = 1.5  // отношение острой нагрузки к хронической, выше которого растет риск перетренированности
+)
+
+// TrainingLoad возвращает нагрузку тренировки в условных единицах.
+// Если у тренировки Бег задан средний пульс, а в профиле пульс в покое и максимальный пульс или возраст,
+// нагрузка считается как TRIMP Банистера:
+// длительность_в_минутах * доля_резерва_пульса * 0.64 * e^(1.92 * доля_резерва_пульса)
+// Без пульса интенсивность неизвестна, и нагрузка - это только замена по калориям: калории / 10.
+// Она не говорит о тренировке больше, чем калории, но для тренировки средней интенсивности
+// близка к TRIMP и позволяет учитывать такие тренировки в AcuteChronicLoad.
+func TrainingLoad(training CaloriesCalculator) float64 {
+	return trainingLoad(training, readInfo(training))
+}
+
+// trainingLoad возвращает нагрузку тренировки для уже посчитанной информации о ней.
+func trainingLoad(training CaloriesCalculator, info InfoMessage) float64 {
+	minutes := info.Duration.Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	if r, ok := training.(Running); ok && r.Profile != nil {
+		if reserve := r.Profile.heartRateReserve(r.AvgHeartRate); reserve > 0 {
+			return minutes * reserve * TRIMPMultiplier * math.Exp(TRIMPExponent*reserve)
+		}
+	}
+	if !(info.Calories > 0) {
+		return 0
+	}
+	return info.Calories / LoadKcalPerUnit
+}
+
+// LoadRatio острая и хроническая нагрузка на день.
+type LoadRatio struct {
+	Day     time.Time // день, которым заканчиваются окна нагрузки
+	Acute   float64   // средняя дневная нагрузка за AcuteLoadDays дней
+	Chronic float64   // средняя дневная нагрузка за ChronicLoadDays дней
+}
+
+// Ratio возвращает отношение острой нагрузки к хронической или 0, если хронической нагрузки нет.
+func (r LoadRatio) Ratio() float64 {
+	if r.Chronic == 0 {
+		return 0
+	}
+	return r.Acute / r.Chronic
+}
+
+// String возвращает строку с нагрузкой на день.
+func (r LoadRatio) String() string {
+	return fmt.Sprintf("Нагрузка на %s: острая %.1f, хроническая %.1f, отношение %.2f",
+		r.Day.Format("02.01.2006"), r.Acute, r.Chronic, r.Ratio())
+}
+
+// AcuteChronicLoad считает острую и хроническую нагрузку по тренировкам, которые начались
+// в течение AcuteLoadDays и ChronicLoadDays календарных дней, заканчивающихся днем day включительно.
+// Нагрузка каждой тренировки считается TrainingLoad. Тренировки без времени начала пропускаются.
+func AcuteChronicLoad(actual []CaloriesCalculator, day time.Time) LoadRatio {
+	end := CalendarDay(day).AddDate(0, 0, 1)
+	acuteStart := end.AddDate(0, 0, -AcuteLoadDays)
+	chronicStart := end.AddDate(0, 0, -ChronicLoadDays)
+	ratio := LoadRatio{Day: CalendarDay(day)}
+
+	var acute, chronic float64
+	for _, training := range actual {
+		info := readInfo(training)
+		if info.StartedAt.IsZero() {
+			continue
+		}
+		started := info.StartedAt.In(end.Location())
+		if started.Before(chronicStart) || !started.Before(end) {
+			continue
+		}
+		load := trainingLoad(training, info)
+		chronic += load
+		if !started.Before(acuteStart) {
+			acute += load
+		}
+	}
+	ratio.Acute = acute / AcuteLoadDays
+	ratio.Chronic = chronic / ChronicLoadDays
+	return ratio
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTrainingLoad(t *testing.T) {
+	run := Running{Training: Training{TrainingType: "Бег", Action: 9000, LenStep: LenStep, Duration: time.Hour, Weight: 70}}
+	withHR := run
+	withHR.AvgHeartRate = 150
+	withHR.Profile = &UserProfile{RestingHR: 50, MaxHR: 190}
+	byAge := withHR
+	byAge.Profile = &UserProfile{RestingHR: 60, Age: 40}
+	noResting := withHR
+	noResting.Profile = &UserProfile{MaxHR: 190}
+	walk := Walking{Training: Training{Action: 6000, LenStep: LenStep, Duration: time.Hour, Weight: 70}, Height: 175}
+
+	// доля резерва пульса (150 - 50) / (190 - 50) = 5/7
+	reserve := 5.0 / 7
+	// по возрасту макс. пульс 208 - 0.7 * 40 = 180, доля резерва (150 - 60) / (180 - 60) = 0.75
+	tests := []struct {
+		name     string
+		training CaloriesCalculator
+		want     float64
+	}{
+		{"trimp", withHR, 60 * reserve * TRIMPMultiplier * math.Exp(TRIMPExponent*reserve)},
+		{"trimp max hr by age", byAge, 60 * 0.75 * TRIMPMultiplier * math.Exp(TRIMPExponent*0.75)},
+		{"no heart rate", run, run.Calories() / LoadKcalPerUnit},
+		{"no resting heart rate", noResting, run.Calories() / LoadKcalPerUnit},
+		{"walking", walk, walk.Calories() / LoadKcalPerUnit},
+		{"zero duration", Running{Training: Training{Action: 9000, LenStep: LenStep, Weight: 70}}, 0},
+		{"nan weight", Running{Training: Training{Action: 9000, LenStep: LenStep, Duration: time.Hour, Weight: math.NaN()}}, 0},
+	}
+	for _, tt := range tests {
+		if got := TrainingLoad(tt.training); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: TrainingLoad() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := TrainingLoad(withHR); math.Abs(got-108.1) > 0.05 {
+		t.Errorf("TrainingLoad() for 1h at 5/7 of heart rate reserve = %v, want about 108.1", got)
+	}
+}
+
+func TestAcuteChronicLoad(t *testing.T) {
+	day := time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC)
+	workout := func(daysAgo int) CaloriesCalculator {
+		return Running{Training: Training{
+			Action:    9000,
+			LenStep:   LenStep,
+			Duration:  time.Hour,
+			Weight:    70,
+			StartedAt: day.AddDate(0, 0, -daysAgo).Add(18 * time.Hour),
+		}}
+	}
+	actual := []CaloriesCalculator{
+		workout(0), workout(6), // острое и хроническое окно
+		workout(7), workout(27), // только хроническое окно
+		workout(28), workout(-1), // вне окон
+		Running{Training: Training{Action: 9000, LenStep: LenStep, Duration: time.Hour, Weight: 70}}, // без времени начала
+	}
+	load := TrainingLoad(workout(0))
+
+	got := AcuteChronicLoad(actual, day.Add(9*time.Hour))
+	if !got.Day.Equal(day) {
+		t.Errorf("Day = %v, want %v", got.Day, day)
+	}
+	if want := 2 * load / AcuteLoadDays; math.Abs(got.Acute-want) > 1e-9 {
+		t.Errorf("Acute = %v, want %v", got.Acute, want)
+	}
+	if want := 4 * load / ChronicLoadDays; math.Abs(got.Chronic-want) > 1e-9 {
+		t.Errorf("Chronic = %v, want %v", got.Chronic, want)
+	}
+	if want := 2.0; math.Abs(got.Ratio()-want) > 1e-9 {
+		t.Errorf("Ratio() = %v, want %v", got.Ratio(), want)
+	}
+	if got := (LoadRatio{}).Ratio(); got != 0 {
+		t.Errorf("Ratio() without chronic load = %v, want 0", got)
+	}
+}
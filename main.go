@@ -1,8 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
 	"math"
+	"os"
+	"path/filepath"
+	"text/template"
 	"time"
 )
 
@@ -14,67 +19,149 @@ const (
 	CmInM      = 100  // количество сантиметров в одном метре
 )
 
+// Границы параметров тренировки, которые принимает Validate. В этих границах формулы калорий не переполняются.
+const (
+	MaxWeight             = 500  // наибольший вес пользователя в кг
+	MaxLenStep            = 20   // наибольшая длина шага или гребка в м
+	MinHeight             = 50   // наименьший рост пользователя в см
+	MaxHeight             = 300  // наибольший рост пользователя в см
+	MaxCadence            = 300  // наибольший каденс в шагах в минуту
+	MaxDistance           = 1000 // наибольшая заданная дистанция в км
+	MaxFormulaCoefficient = 1000 // наибольший коэффициент формулы калорий
+)
+
 // Training общая структура для всех тренировок
 type Training struct {
-	...        	// тип тренировки
-	...         // количество повторов(шаги, гребки при плавании)
-	...       	// длина одного шага или гребка в м
-	... 		// продолжительность тренировки
-	...       	// вес пользователя в кг
+	TrainingType string          // тип тренировки
+	Action       int             // количество повторов(шаги, гребки при плавании)
+	LenStep      float64         // длина одного шага или гребка в м
+	Duration     time.Duration   // продолжительность тренировки
+	Weight       float64         // вес пользователя в кг
+	StartedAt    time.Time       // время начала тренировки с часовым поясом, может быть не задано
+	Logger       *slog.Logger    // логгер для отладки расчета калорий, nil - без логирования
+	Profile      *UserProfile    // профиль пользователя для возрастной поправки калорий, nil - взрослый
+	Estimated    EstimatedFields // поля, которые не были измерены и заполнены WithEstimates
 }
 
 // distance возвращает дистанцию, которую преодолел пользователь.
 // Формула расчета:
 // количество_повторов * длина_шага / м_в_км
 func (t Training) distance() float64 {
-	// вставьте ваш код ниже
-	...
+	return float64(t.Action) * t.LenStep / MInKm
 }
 
 // meanSpeed возвращает среднюю скорость бега или ходьбы.
 func (t Training) meanSpeed() float64 {
-	// вставьте ваш код ниже
-	...	
+	return speedKmh(t.distance(), t.Duration)
+}
+
+// speedKmh возвращает среднюю скорость в км/ч для дистанции в км, пройденной за duration.
+// Для нулевой или отрицательной продолжительности возвращает 0.
+func speedKmh(distance float64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return distance / duration.Hours()
+}
+
+// Validate проверяет общие параметры тренировки и профиль пользователя: значения не отрицательные,
+// не NaN и не выходят за границы. Validate каждого типа тренировки дополнительно проверяет свои поля,
+// и формулы калорий дают неотрицательный и конечный результат для любой тренировки,
+// которая прошла Validate своего типа.
+func (t Training) Validate() error {
+	switch {
+	case t.Action < 0:
+		return fmt.Errorf("количество повторов не может быть отрицательным, получено %d", t.Action)
+	case t.Duration < 0:
+		return fmt.Errorf("продолжительность не может быть отрицательной, получено %v", t.Duration)
+	case !inRange(t.LenStep, 0, MaxLenStep):
+		return fmt.Errorf("длина шага должна быть от 0 до %d м, получено %v", MaxLenStep, t.LenStep)
+	case !inRange(t.Weight, 0, MaxWeight):
+		return fmt.Errorf("вес должен быть от 0 до %d кг, получено %v", MaxWeight, t.Weight)
+	}
+	if t.Profile != nil {
+		return t.Profile.Validate()
+	}
+	return nil
+}
+
+// isNonNegative сообщает, что v - конечное неотрицательное число.
+func isNonNegative(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 1)
+}
+
+// inRange сообщает, что v лежит в отрезке от min до max. Для NaN возвращает false.
+func inRange(v, min, max float64) bool {
+	return v >= min && v <= max
+}
+
+// validateCoefficients проверяет, что коэффициенты формулы калорий лежат от 0 до MaxFormulaCoefficient.
+func validateCoefficients(coefficients ...float64) error {
+	for _, c := range coefficients {
+		if !inRange(c, 0, MaxFormulaCoefficient) {
+			return fmt.Errorf("коэффициент формулы калорий должен быть от 0 до %d, получено %v", MaxFormulaCoefficient, c)
+		}
+	}
+	return nil
 }
 
 // Calories возвращает количество потраченных килокалорий на тренировке.
 // Пока возвращаем 0, так как этот метод будет переопределяться для каждого типа тренировки.
 func (t Training) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	return 0
 }
 
 // InfoMessage содержит информацию о проведенной тренировке.
 type InfoMessage struct {
-	// добавьте необходимые поля в структуру
-	...     // тип тренировки
-	... 	// длительность тренировки
-	...     // расстояние, которое преодолел пользователь
-	...     // средняя скорость, с которой двигался пользователь
-	...     // количество потраченных килокалорий на тренировке
+	TrainingType string          // тип тренировки
+	StartedAt    time.Time       // время начала тренировки
+	Duration     time.Duration   // длительность тренировки
+	Distance     float64         // расстояние, которое преодолел пользователь
+	Speed        float64         // средняя скорость, с которой двигался пользователь
+	Calories     float64         // количество потраченных килокалорий на тренировке
+	Cadence      float64         // каденс в шагах в минуту, 0 - не считается для этого типа
+	StrideLength float64         // фактическая длина шага в м, 0 - дистанция не измерялась отдельно от шагов
+	Fueling      Fueling         // оценка потерь жидкости и углеводов, заполняется, если у тренировки заданы условия
+	Estimated    EstimatedFields // поля тренировки, которые оценены, а не измерены
 }
 
 // TrainingInfo возвращает труктуру InfoMessage, в которой хранится вся информация о проведенной тренировке.
 func (t Training) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	distance := t.distance()
+	info := t.info(distance, speedKmh(distance, t.Duration))
+	info.Calories = t.Calories()
+	return info
+}
+
+// info возвращает InfoMessage без калорий для уже посчитанных дистанции и скорости,
+// чтобы переопределенные TrainingInfo не вычисляли их повторно.
+func (t Training) info(distance, speed float64) InfoMessage {
+	return InfoMessage{
+		TrainingType: t.TrainingType,
+		StartedAt:    t.StartedAt,
+		Duration:     t.Duration,
+		Distance:     distance,
+		Speed:        speed,
+		Estimated:    t.Estimated,
+	}
+}
+
+// CalendarDay возвращает начало календарного дня, в который попадает t, в часовом поясе t.
+// В отличие от t.Truncate(24 * time.Hour) корректно работает в дни перехода на летнее время.
+func CalendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
 }
 
 // String возвращает строку с информацией о проведенной тренировке.
 func (i InfoMessage) String() string {
-	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %v мин\nДистанция: %.2f км.\nСр. скорость: %.2f км/ч\nПотрачено ккал: %.2f\n",
-		i.TrainingType,
-		i.Duration.Minutes(),
-		i.Distance,
-		i.Speed,
-		i.Calories,
-	)
+	return DefaultDisplayFormat.Format(i)
 }
 
-// CaloriesCalculator интерфейс для структур: Running, Walking и Swimming.
+// CaloriesCalculator интерфейс для структур: Running, Walking, Swimming и Rowing.
 type CaloriesCalculator interface {
-	// добавьте необходимые методы в интерфейс
-	...
+	Calories() float64
+	TrainingInfo() InfoMessage
 }
 
 // Константы для расчета потраченных килокалорий при беге.
@@ -83,26 +170,159 @@ const (
 	CaloriesMeanSpeedShift      = 1.79 // коэффициент изменения средней скорости
 )
 
+// RunningFormula коэффициенты формулы расчета калорий при беге.
+type RunningFormula struct {
+	MeanSpeedMultiplier float64 // множитель средней скорости бега
+	MeanSpeedShift      float64 // коэффициент изменения средней скорости
+}
+
+// DefaultRunningFormula коэффициенты, которые используются, если у тренировки не задана своя формула.
+var DefaultRunningFormula = RunningFormula{
+	MeanSpeedMultiplier: CaloriesMeanSpeedMultiplier,
+	MeanSpeedShift:      CaloriesMeanSpeedShift,
+}
+
 // Running структура, описывающая тренировку Бег.
 type Running struct {
-	// добавьте необходимые поля в структуру
-	...
+	Training
+	Cadence           float64         // каденс в шагах в минуту, используется, если не задан Action
+	TreadmillDistance float64         // дистанция по беговой дорожке в км, если задана, шаги для дистанции не учитываются
+	MeasuredDistance  float64         // дистанция в км по GPS или другому измерению на улице, учитывается так же
+	AvgHeartRate      float64         // средний пульс за тренировку в уд/мин, 0 - не измерялся
+	Environment       *Environment    // условия тренировки на улице, nil - без поправки калорий
+	Formula           *RunningFormula // коэффициенты формулы калорий, nil - DefaultRunningFormula
+}
+
+// Validate проверяет параметры тренировки, каденс, дистанцию по дорожке, пульс, условия и формулу.
+func (r Running) Validate() error {
+	if err := r.Training.Validate(); err != nil {
+		return err
+	}
+	switch {
+	case !inRange(r.Cadence, 0, MaxCadence):
+		return fmt.Errorf("каденс должен быть от 0 до %d шагов в минуту, получено %v", MaxCadence, r.Cadence)
+	case !inRange(r.TreadmillDistance, 0, MaxDistance):
+		return fmt.Errorf("дистанция по дорожке должна быть от 0 до %d км, получено %v", MaxDistance, r.TreadmillDistance)
+	case !inRange(r.MeasuredDistance, 0, MaxDistance):
+		return fmt.Errorf("измеренная дистанция должна быть от 0 до %d км, получено %v", MaxDistance, r.MeasuredDistance)
+	case !isNonNegative(r.AvgHeartRate):
+		return fmt.Errorf("пульс должен быть неотрицательным числом, получено %v", r.AvgHeartRate)
+	}
+	if err := r.Environment.Validate(); err != nil {
+		return err
+	}
+	f := r.formula()
+	return validateCoefficients(f.MeanSpeedMultiplier, f.MeanSpeedShift)
+}
+
+// steps возвращает количество шагов за тренировку.
+// Если Action не задан, шаги считаются по каденсу:
+// каденс * время_тренировки_в_минутах
+func (r Running) steps() float64 {
+	if r.Action > 0 {
+		return float64(r.Action)
+	}
+	return r.Cadence * r.Duration.Minutes()
+}
+
+// cadence возвращает каденс бега в шагах в минуту.
+// Если каденс не задан, он вычисляется из количества шагов и продолжительности.
+func (r Running) cadence() float64 {
+	if r.Cadence > 0 {
+		return r.Cadence
+	}
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.Action) / r.Duration.Minutes()
+}
+
+// measuredDistance возвращает дистанцию в км, измеренную отдельно от шагов, или 0, если она не задана.
+// Дистанция по дорожке важнее дистанции по GPS.
+func (r Running) measuredDistance() float64 {
+	if r.TreadmillDistance > 0 {
+		return r.TreadmillDistance
+	}
+	return r.MeasuredDistance
+}
+
+// distance возвращает дистанцию бега в км.
+// Если дистанция измерена на дорожке или по GPS, это она, иначе количество_шагов * длина_шага / м_в_км.
+// Это переопределенный метод distance() из Training.
+func (r Running) distance() float64 {
+	if d := r.measuredDistance(); d > 0 {
+		return d
+	}
+	return r.steps() * r.LenStep / MInKm
+}
+
+// meanSpeed возвращает среднюю скорость бега.
+// Это переопределенный метод meanSpeed() из Training.
+func (r Running) meanSpeed() float64 {
+	return speedKmh(r.distance(), r.Duration)
+}
+
+// strideLength возвращает фактическую длину шага в м для дистанции в км:
+// дистанция_в_м / количество_шагов
+func strideLength(distance, steps float64) float64 {
+	if steps == 0 {
+		return 0
+	}
+	return distance * MInKm / steps
+}
+
+// formula возвращает коэффициенты, по которым считаются калории для этой тренировки.
+func (r Running) formula() RunningFormula {
+	if r.Formula == nil {
+		return DefaultRunningFormula
+	}
+	return *r.Formula
 }
 
 // Calories возввращает количество потраченных килокалория при беге.
 // Формула расчета:
 // ((18 * средняя_скорость_в_км/ч + 1.79) * вес_спортсмена_в_кг / м_в_км * время_тренировки_в_часах * мин_в_часе)
+// Если заданы условия тренировки или профиль пользователя, результат умножается на поправки для них.
 // Это переопределенный метод Calories() из Training.
 func (r Running) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	return r.calories(r.meanSpeed())
+}
+
+// calories возвращает калории при беге для уже посчитанной средней скорости в км/ч.
+func (r Running) calories(speed float64) float64 {
+	f := r.formula()
+	env := r.Environment.multiplier()
+	age := r.Profile.caloriesMultiplier()
+	calories := (f.MeanSpeedMultiplier*speed + f.MeanSpeedShift) * r.Weight / MInKm * r.Duration.Hours() * MinInHours * env * age
+	if r.Logger != nil {
+		r.logCalories(calories,
+			slog.Float64("distance", r.distance()),
+			slog.Float64("mean_speed", speed),
+			slog.Float64("mean_speed_multiplier", f.MeanSpeedMultiplier),
+			slog.Float64("mean_speed_shift", f.MeanSpeedShift),
+			slog.Float64("environment_multiplier", env),
+			slog.Float64("age_multiplier", age),
+		)
+	}
+	return calories
 }
 
 // TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
 // Это переопределенный метод TrainingInfo() из Training.
 func (r Running) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	distance := r.distance()
+	speed := speedKmh(distance, r.Duration)
+
+	info := r.info(distance, speed)
+	info.Calories = r.calories(speed)
+	info.Fueling = r.Environment.fueling(info)
+	info.Cadence = r.cadence()
+	// длина шага из дистанции, посчитанной по шагам, всегда равна LenStep, поэтому она
+	// сообщается только для дистанции, измеренной отдельно
+	if r.measuredDistance() > 0 {
+		info.StrideLength = strideLength(distance, r.steps())
+	}
+	return info
 }
 
 // Константы для расчета потраченных килокалорий при ходьбе.
@@ -112,28 +332,138 @@ const (
 	KmHInMsec                     = 0.278 // коэффициент для перевода км/ч в м/с
 )
 
+// Константы для вариантов ходьбы.
+const (
+	WalkingTrainingType     = "Ходьба"               // тип тренировки, который уточняется для вариантов
+	NordicWalkingType       = "Скандинавская ходьба" // тип тренировки при ходьбе с палками
+	HikingType              = "Поход"                // тип тренировки при ходьбе с рюкзаком
+	NordicHikingType        = "Поход с палками"      // тип тренировки при ходьбе с рюкзаком и палками
+	NordicWalkingMultiplier = 1.2                    // множитель калорий при ходьбе с палками
+	MaxBackpackWeight       = 40                     // наибольший допустимый вес рюкзака в кг
+)
+
+// WalkingFormula коэффициенты формулы расчета калорий при ходьбе.
+type WalkingFormula struct {
+	WeightMultiplier      float64 // коэффициент для веса
+	SpeedHeightMultiplier float64 // коэффициент для роста
+}
+
+// DefaultWalkingFormula коэффициенты, которые используются, если у тренировки не задана своя формула.
+var DefaultWalkingFormula = WalkingFormula{
+	WeightMultiplier:      CaloriesWeightMultiplier,
+	SpeedHeightMultiplier: CaloriesSpeedHeightMultiplier,
+}
+
 // Walking структура описывающая тренировку Ходьба
 type Walking struct {
-	// добавьте необходимые поля в структуру
-	...
-	... // рост пользователя
+	Training
+	Height         float64         // рост пользователя
+	Formula        *WalkingFormula // коэффициенты формулы калорий, nil - DefaultWalkingFormula
+	Environment    *Environment    // условия тренировки на улице, nil - без поправки калорий
+	Poles          bool            // скандинавская ходьба с палками
+	BackpackWeight float64         // вес рюкзака в кг для похода, от 0 до MaxBackpackWeight
+}
+
+// Validate проверяет параметры тренировки, рост, параметры вариантов ходьбы, условия и формулу.
+func (w Walking) Validate() error {
+	if err := w.Training.Validate(); err != nil {
+		return err
+	}
+	if !inRange(w.Height, MinHeight, MaxHeight) {
+		return fmt.Errorf("рост должен быть от %d до %d см, получено %v", MinHeight, MaxHeight, w.Height)
+	}
+	if !inRange(w.BackpackWeight, 0, MaxBackpackWeight) {
+		return fmt.Errorf("вес рюкзака должен быть от 0 до %d кг, получено %v", MaxBackpackWeight, w.BackpackWeight)
+	}
+	if err := w.Environment.Validate(); err != nil {
+		return err
+	}
+	f := w.formula()
+	return validateCoefficients(f.WeightMultiplier, f.SpeedHeightMultiplier)
+}
+
+// trainingType возвращает тип тренировки с учетом палок и рюкзака.
+// Тип уточняется, только если он не задан или равен WalkingTrainingType.
+func (w Walking) trainingType() string {
+	if w.TrainingType != "" && w.TrainingType != WalkingTrainingType {
+		return w.TrainingType
+	}
+	switch {
+	case w.Poles && w.BackpackWeight > 0:
+		return NordicHikingType
+	case w.Poles:
+		return NordicWalkingType
+	case w.BackpackWeight > 0:
+		return HikingType
+	}
+	return w.TrainingType
+}
+
+// formula возвращает коэффициенты, по которым считаются калории для этой тренировки.
+func (w Walking) formula() WalkingFormula {
+	if w.Formula == nil {
+		return DefaultWalkingFormula
+	}
+	return *w.Formula
 }
 
 // Calories возвращает количество потраченных килокалорий при ходьбе.
 // Формула расчета:
 // ((0.035 * вес_спортсмена_в_кг + (средняя_скорость_в_метрах_в_секунду**2 / рост_в_метрах)
 // * 0.029 * вес_спортсмена_в_кг) * время_тренировки_в_часах * мин_в_ч)
+// В походе к весу спортсмена прибавляется вес рюкзака, при ходьбе с палками результат умножается на 1.2.
+// Если заданы условия тренировки или профиль пользователя, результат умножается на поправки для них.
 // Это переопределенный метод Calories() из Training.
 func (w Walking) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	return w.calories(w.meanSpeed())
+}
+
+// calories возвращает калории при ходьбе для уже посчитанной средней скорости в км/ч.
+func (w Walking) calories(speed float64) float64 {
+	f := w.formula()
+	speedMs := speed * KmHInMsec
+	height := w.Height / CmInM
+	// без роста слагаемое со скоростью не определено, остается только слагаемое с весом
+	speedTerm := 0.0
+	if height > 0 {
+		speedTerm = speedMs * speedMs / height
+	}
+	env := w.Environment.multiplier()
+	age := w.Profile.caloriesMultiplier()
+	weight := w.Weight + math.Max(0, w.BackpackWeight)
+	poles := 1.0
+	if w.Poles {
+		poles = NordicWalkingMultiplier
+	}
+	calories := (f.WeightMultiplier*weight + speedTerm*f.SpeedHeightMultiplier*weight) * w.Duration.Hours() * MinInHours * env * age * poles
+	if w.Logger != nil {
+		w.logCalories(calories,
+			slog.Float64("distance", w.distance()),
+			slog.Float64("mean_speed", speed),
+			slog.Float64("mean_speed_ms", speedMs),
+			slog.Float64("height_m", height),
+			slog.Float64("weight_multiplier", f.WeightMultiplier),
+			slog.Float64("speed_height_multiplier", f.SpeedHeightMultiplier),
+			slog.Float64("backpack_weight", w.BackpackWeight),
+			slog.Float64("poles_multiplier", poles),
+			slog.Float64("environment_multiplier", env),
+			slog.Float64("age_multiplier", age),
+		)
+	}
+	return calories
 }
 
 // TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
 // Это переопределенный метод TrainingInfo() из Training.
 func (w Walking) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	distance := w.distance()
+	speed := speedKmh(distance, w.Duration)
+
+	info := w.info(distance, speed)
+	info.TrainingType = w.trainingType()
+	info.Calories = w.calories(speed)
+	info.Fueling = w.Environment.fueling(info)
+	return info
 }
 
 // Константы для расчета потраченных килокалорий при плавании.
@@ -143,12 +473,44 @@ const (
 	SwimmingCaloriesWeightMultiplier = 2    // множитель веса пользователя
 )
 
+// SwimmingFormula коэффициенты формулы расчета калорий при плавании.
+type SwimmingFormula struct {
+	MeanSpeedShift   float64 // коэффициент изменения средней скорости
+	WeightMultiplier float64 // множитель веса пользователя
+}
+
+// DefaultSwimmingFormula коэффициенты, которые используются, если у тренировки не задана своя формула.
+var DefaultSwimmingFormula = SwimmingFormula{
+	MeanSpeedShift:   SwimmingCaloriesMeanSpeedShift,
+	WeightMultiplier: SwimmingCaloriesWeightMultiplier,
+}
+
 // Swimming структура, описывающая тренировку Плавание
 type Swimming struct {
-	// добавьте необходимые поля в структуру
-	...
-	... // длина бассейна
-	... // количество пересечений бассейна
+	Training
+	LengthPool int              // длина бассейна
+	CountPool  int              // количество пересечений бассейна
+	Formula    *SwimmingFormula // коэффициенты формулы калорий, nil - DefaultSwimmingFormula
+}
+
+// Validate проверяет параметры тренировки, бассейна и формулу.
+func (s Swimming) Validate() error {
+	if err := s.Training.Validate(); err != nil {
+		return err
+	}
+	if s.LengthPool < 0 || s.CountPool < 0 {
+		return fmt.Errorf("длина и количество пересечений бассейна не могут быть отрицательными, получено %d и %d", s.LengthPool, s.CountPool)
+	}
+	f := s.formula()
+	return validateCoefficients(f.MeanSpeedShift, f.WeightMultiplier)
+}
+
+// formula возвращает коэффициенты, по которым считаются калории для этой тренировки.
+func (s Swimming) formula() SwimmingFormula {
+	if s.Formula == nil {
+		return DefaultSwimmingFormula
+	}
+	return *s.Formula
 }
 
 // meanSpeed возвращает среднюю скорость при плавании.
@@ -156,40 +518,223 @@ type Swimming struct {
 // длина_бассейна * количество_пересечений / м_в_км / продолжительность_тренировки
 // Это переопределенный метод Calories() из Training.
 func (s Swimming) meanSpeed() float64 {
-	// вставьте ваш код ниже
-	...
+	return speedKmh(float64(s.LengthPool)*float64(s.CountPool)/MInKm, s.Duration)
 }
 
 // Calories возвращает количество калорий, потраченных при плавании.
 // Формула расчета:
 // (средняя_скорость_в_км/ч + SwimmingCaloriesMeanSpeedShift) * SwimmingCaloriesWeightMultiplier * вес_спортсмена_в_кг * время_тренировки_в_часах
+// Если задан профиль пользователя, результат умножается на возрастную поправку.
 // Это переопределенный метод Calories() из Training.
 func (s Swimming) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	return s.calories(s.meanSpeed())
+}
+
+// calories возвращает калории при плавании для уже посчитанной средней скорости в км/ч.
+func (s Swimming) calories(speed float64) float64 {
+	f := s.formula()
+	age := s.Profile.caloriesMultiplier()
+	calories := (speed + f.MeanSpeedShift) * f.WeightMultiplier * s.Weight * s.Duration.Hours() * age
+	if s.Logger != nil {
+		s.logCalories(calories,
+			slog.Int("length_pool", s.LengthPool),
+			slog.Int("count_pool", s.CountPool),
+			slog.Float64("mean_speed", speed),
+			slog.Float64("mean_speed_shift", f.MeanSpeedShift),
+			slog.Float64("weight_multiplier", f.WeightMultiplier),
+			slog.Float64("age_multiplier", age),
+		)
+	}
+	return calories
 }
 
 // TrainingInfo returns info about swimming training.
 // Это переопределенный метод TrainingInfo() из Training.
 func (s Swimming) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	speed := s.meanSpeed()
+
+	info := s.info(s.distance(), speed)
+	info.Calories = s.calories(speed)
+	return info
+}
+
+// Константы для расчета потраченных килокалорий на гребном тренажере (формула Concept2).
+const (
+	RowingLenStep          = 10     // средняя дистанция одного гребка в м
+	RowingSplitDistance    = 500    // дистанция, на которую считается темп, в м
+	RowingPowerCoefficient = 2.80   // коэффициент перевода темпа в мощность
+	RowingKcalPerWattHour  = 0.8604 // килокалорий в одном ватт-часе
+	RowingEfficiencyFactor = 4      // отношение затраченной энергии к мощности на рукоятке
+	RowingBaseKcalPerHour  = 300    // базовый расход килокалорий в час
+)
+
+// RowingFormula коэффициенты формулы расчета калорий на гребном тренажере.
+type RowingFormula struct {
+	PowerCoefficient float64 // коэффициент перевода темпа в мощность
+	EfficiencyFactor float64 // отношение затраченной энергии к мощности на рукоятке
+	BaseKcalPerHour  float64 // базовый расход килокалорий в час
+}
+
+// DefaultRowingFormula коэффициенты, которые используются, если у тренировки не задана своя формула.
+var DefaultRowingFormula = RowingFormula{
+	PowerCoefficient: RowingPowerCoefficient,
+	EfficiencyFactor: RowingEfficiencyFactor,
+	BaseKcalPerHour:  RowingBaseKcalPerHour,
+}
+
+// Rowing структура, описывающая тренировку на гребном тренажере.
+// Дистанция берется из Meters, если он задан, иначе из Split и продолжительности, иначе из Action * LenStep.
+type Rowing struct {
+	Training
+	Meters  int            // дистанция по монитору тренажера в м
+	Split   time.Duration  // средний темп на 500 м
+	Formula *RowingFormula // коэффициенты формулы калорий, nil - DefaultRowingFormula
+}
+
+// Validate проверяет параметры тренировки, дистанцию, темп и формулу.
+func (r Rowing) Validate() error {
+	if err := r.Training.Validate(); err != nil {
+		return err
+	}
+	if r.Meters < 0 || r.Split < 0 {
+		return fmt.Errorf("дистанция и темп не могут быть отрицательными, получено %d и %v", r.Meters, r.Split)
+	}
+	f := r.formula()
+	return validateCoefficients(f.PowerCoefficient, f.EfficiencyFactor, f.BaseKcalPerHour)
+}
+
+// formula возвращает коэффициенты, по которым считаются калории для этой тренировки.
+func (r Rowing) formula() RowingFormula {
+	if r.Formula == nil {
+		return DefaultRowingFormula
+	}
+	return *r.Formula
+}
+
+// distance возвращает дистанцию гребли в км.
+// Это переопределенный метод distance() из Training.
+func (r Rowing) distance() float64 {
+	switch {
+	case r.Meters > 0:
+		return float64(r.Meters) / MInKm
+	case r.Split > 0:
+		return float64(r.Duration) / float64(r.Split) * RowingSplitDistance / MInKm
+	}
+	return r.Training.distance()
+}
+
+// pace возвращает средний темп в секундах на метр для уже посчитанной дистанции в км
+// или 0, если дистанция не пройдена. Темп считается в float64, а не в time.Duration,
+// так как для очень малой дистанции он не помещается в time.Duration.
+func (r Rowing) pace(distance float64) float64 {
+	if r.Split > 0 {
+		return r.Split.Seconds() / RowingSplitDistance
+	}
+	if distance <= 0 {
+		return 0
+	}
+	return r.Duration.Seconds() / (distance * MInKm)
+}
+
+// Calories возвращает количество потраченных килокалорий на гребном тренажере.
+// Формула расчета:
+// (мощность_в_Вт * 4 * 0.8604 + 300) * время_тренировки_в_часах
+// мощность_в_Вт = 2.80 / темп_в_секундах_на_метр**3
+// Если задан профиль пользователя, результат умножается на возрастную поправку.
+// Это переопределенный метод Calories() из Training.
+func (r Rowing) Calories() float64 {
+	return r.calories(r.distance())
+}
+
+// calories возвращает калории на гребном тренажере для уже посчитанной дистанции в км.
+func (r Rowing) calories(distance float64) float64 {
+	pace := r.pace(distance)
+	if pace == 0 {
+		return 0
+	}
+	f := r.formula()
+	watts := f.PowerCoefficient / (pace * pace * pace)
+	age := r.Profile.caloriesMultiplier()
+	calories := (watts*f.EfficiencyFactor*RowingKcalPerWattHour + f.BaseKcalPerHour) * r.Duration.Hours() * age
+	if r.Logger != nil {
+		r.logCalories(calories,
+			slog.Float64("distance", distance),
+			slog.Float64("pace", pace),
+			slog.Float64("watts", watts),
+			slog.Float64("power_coefficient", f.PowerCoefficient),
+			slog.Float64("efficiency_factor", f.EfficiencyFactor),
+			slog.Float64("base_kcal_per_hour", f.BaseKcalPerHour),
+			slog.Float64("age_multiplier", age),
+		)
+	}
+	return calories
+}
+
+// TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
+// Это переопределенный метод TrainingInfo() из Training.
+func (r Rowing) TrainingInfo() InfoMessage {
+	distance := r.distance()
+
+	info := r.info(distance, speedKmh(distance, r.Duration))
+	info.Calories = r.calories(distance)
+	return info
+}
+
+// readInfo собирает InfoMessage с посчитанными калориями для любой тренировки.
+// Встроенные типы уже считают калории в TrainingInfo, поэтому для них Calories() отдельно не вызывается.
+// Другие тренировки могут встраивать Training и переопределять только Calories(),
+// поэтому для них калории берутся из Calories().
+func readInfo(training CaloriesCalculator) InfoMessage {
+	info := training.TrainingInfo()
+	switch training.(type) {
+	case Running, Walking, Swimming, Rowing, SwimWorkout, MultiSport:
+		return info
+	}
+	info.Calories = training.Calories()
+	return info
 }
 
 // ReadData возвращает информацию о проведенной тренировке.
+// Для составной тренировки после итога выводится информация по каждому этапу.
 func ReadData(training CaloriesCalculator) string {
-	// получите количество затраченных калорий
-	calories := ...
-
-	// получите информацию о тренировке
-	info := ...
-	// добавьте полученные калории в структуру с информацией о тренировке
-	...
+	if m, ok := training.(MultiSport); ok {
+		return fmt.Sprint(m.Info())
+	}
+	return fmt.Sprint(readInfo(training))
+}
 
-	return fmt.Sprint(info)
+// loadInfoTemplate возвращает шаблон вывода из файла path или nil, если путь не задан.
+func loadInfoTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInfoTemplate(filepath.Base(path), string(text), DefaultDisplayFormat)
 }
 
 func main() {
+	format := flag.String("format", "", "файл с шаблоном text/template для вывода тренировок")
+	flag.Parse()
+
+	tmpl, err := loadInfoTemplate(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	show := func(training CaloriesCalculator) {
+		if tmpl == nil {
+			fmt.Println(ReadData(training))
+			return
+		}
+		if err := WriteInfoTemplate(os.Stdout, training, tmpl); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println()
+	}
 
 	swimming := Swimming{
 		Training: Training{
@@ -203,7 +748,7 @@ func main() {
 		CountPool:  5,
 	}
 
-	fmt.Println(ReadData(swimming))
+	show(swimming)
 
 	walking := Walking{
 		Training: Training{
@@ -216,7 +761,7 @@ func main() {
 		Height: 185,
 	}
 
-	fmt.Println(ReadData(walking))
+	show(walking)
 
 	running := Running{
 		Training: Training{
@@ -228,6 +773,6 @@ func main() {
 		},
 	}
 
-	fmt.Println(ReadData(running))
+	show(running)
 
 }
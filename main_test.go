@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchTrainings тренировки из main для бенчмарков расчета калорий.
+var benchTrainings = map[string]CaloriesCalculator{
+	"Running": Running{Training: Training{TrainingType: "Бег", Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}},
+	"Walking": Walking{Training: Training{TrainingType: "Ходьба", Action: 20000, LenStep: LenStep, Duration: 225 * time.Minute, Weight: 85}, Height: 185},
+	"Swimming": Swimming{Training: Training{TrainingType: "Плавание", Action: 2000, LenStep: SwimmingLenStep, Duration: 90 * time.Minute, Weight: 85},
+		LengthPool: 50, CountPool: 5},
+}
+
+func BenchmarkTrainingInfo(b *testing.B) {
+	for name, training := range benchTrainings {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				training.TrainingInfo()
+			}
+		})
+	}
+}
+
+func BenchmarkReadData(b *testing.B) {
+	for name, training := range benchTrainings {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ReadData(training)
+			}
+		})
+	}
+}
+
+// validator тренировка, которая умеет проверять свои параметры.
+type validator interface {
+	CaloriesCalculator
+	Validate() error
+}
+
+// fuzzWorkout собирает тренировку типа kind из произвольных входных данных.
+// scale умножает продолжительность и все величины, которые растут вместе с ней при том же темпе.
+func fuzzWorkout(kind uint8, action int, lenStep, minutes, weight, extra float64, scale int) validator {
+	training := Training{
+		Action:   action * scale,
+		LenStep:  lenStep,
+		Duration: time.Duration(minutes*float64(time.Minute)) * time.Duration(scale),
+		Weight:   weight,
+	}
+	switch kind % 4 {
+	case 0:
+		return Running{Training: training, TreadmillDistance: extra * float64(scale)}
+	case 1:
+		return Walking{Training: training, Height: extra}
+	case 2:
+		return Swimming{Training: training, LengthPool: 25, CountPool: int(extra) * scale}
+	default:
+		return Rowing{Training: training, Meters: int(extra) * scale}
+	}
+}
+
+// checkCaloriesProperties проверяет инварианты формул калорий для тренировки, собранной fuzzWorkout:
+// калории неотрицательные и конечные, совпадают в Calories и TrainingInfo, не убывают
+// с ростом продолжительности при том же темпе и с ростом веса, равны 0 при нулевой продолжительности.
+func checkCaloriesProperties(t *testing.T, kind uint8, action int, lenStep, minutes, weight, extra float64) {
+	w := fuzzWorkout(kind, action, lenStep, minutes, weight, extra, 1)
+	if w.Validate() != nil {
+		return
+	}
+
+	calories := w.Calories()
+	if !(calories >= 0) || math.IsInf(calories, 0) {
+		t.Fatalf("%#v: Calories() = %v, want non-negative and finite", w, calories)
+	}
+	if got := w.TrainingInfo().Calories; got != calories {
+		t.Fatalf("%#v: TrainingInfo().Calories = %v, Calories() = %v", w, got, calories)
+	}
+
+	// ограничиваем повторы и продолжительность, чтобы их удвоение не переполняло int и time.Duration
+	if action > 1e7 || !inRange(minutes, 0, 10*24*60) || !inRange(extra, 0, 1e6) {
+		return
+	}
+
+	// tolerance допускает ошибку округления: Duration.Hours() удвоенной продолжительности
+	// может отличаться от удвоенного значения в последнем знаке
+	tolerance := 1e-9 * calories
+	if longer := fuzzWorkout(kind, action, lenStep, minutes, weight, extra, 2); longer.Validate() == nil {
+		if got := longer.Calories(); got < calories-tolerance {
+			t.Fatalf("%#v: calories for double duration %v < %v", w, got, calories)
+		}
+	}
+	if heavier := fuzzWorkout(kind, action, lenStep, minutes, weight+1, extra, 1); heavier.Validate() == nil {
+		if got := heavier.Calories(); got < calories-tolerance {
+			t.Fatalf("%#v: calories for weight+1 %v < %v", w, got, calories)
+		}
+	}
+	if idle := fuzzWorkout(kind, action, lenStep, 0, weight, extra, 1); idle.Validate() == nil {
+		if got := idle.Calories(); got != 0 {
+			t.Fatalf("%#v: calories for zero duration = %v, want 0", idle, got)
+		}
+	}
+}
+
+func FuzzCalories(f *testing.F) {
+	f.Add(uint8(0), 5000, LenStep, 30.0, 85.0, 0.0)
+	f.Add(uint8(0), 6000, LenStep, 45.0, 80.0, 8.0)
+	f.Add(uint8(1), 20000, LenStep, 225.0, 85.0, 185.0)
+	f.Add(uint8(2), 2000, SwimmingLenStep, 90.0, 85.0, 5.0)
+	f.Add(uint8(3), 600, float64(RowingLenStep), 25.0, 80.0, 0.0)
+	f.Add(uint8(3), 0, 0.0, 20.0, 80.0, 5000.0)
+	f.Add(uint8(1), 100, LenStep, 0.0, 0.0, 150.0)
+	f.Fuzz(func(t *testing.T, kind uint8, action int, lenStep, minutes, weight, extra float64) {
+		checkCaloriesProperties(t, kind, action, lenStep, minutes, weight, extra)
+	})
+}
+
+func TestCaloriesProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		checkCaloriesProperties(t,
+			uint8(rnd.Intn(4)),
+			rnd.Intn(100000),
+			rnd.Float64()*MaxLenStep,
+			rnd.Float64()*600,
+			rnd.Float64()*MaxWeight,
+			rnd.Float64()*MaxHeight,
+		)
+	}
+	// нулевая продолжительность не должна приводить к панике или делению на ноль
+	for kind := uint8(0); kind < 4; kind++ {
+		checkCaloriesProperties(t, kind, 1000, LenStep, 0, 70, 180)
+		checkCaloriesProperties(t, kind, 0, 0, 0, 0, 0)
+	}
+}
+
+func TestValidateRejectsInvalidInputs(t *testing.T) {
+	base := Training{Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}
+	nan := math.NaN()
+	tests := []struct {
+		name     string
+		training validator
+	}{
+		{"negative formula", Running{Training: base, Formula: &RunningFormula{MeanSpeedMultiplier: -100}}},
+		{"nan formula", Walking{Training: base, Height: 180, Formula: &WalkingFormula{WeightMultiplier: nan}}},
+		{"huge formula", Swimming{Training: base, Formula: &SwimmingFormula{WeightMultiplier: 1e308}}},
+		{"negative rowing formula", Rowing{Training: base, Formula: &RowingFormula{BaseKcalPerHour: -1}}},
+		{"nan wind", Running{Training: base, Environment: &Environment{Wind: nan}}},
+		{"infinite temperature", Running{Training: base, Environment: &Environment{Temperature: math.Inf(-1)}}},
+		{"unknown surface", Walking{Training: base, Height: 180, Environment: &Environment{Surface: "ice"}}},
+		{"nan backpack", Walking{Training: base, Height: 180, BackpackWeight: nan}},
+		{"nan height", Walking{Training: base, Height: nan}},
+		{"tiny height", Walking{Training: base, Height: 1e-300}},
+		{"huge weight", Running{Training: Training{Action: 5000, LenStep: LenStep, Duration: time.Hour, Weight: 1e308}}},
+		{"huge step", Running{Training: Training{Action: 5000, LenStep: 1e308, Duration: time.Hour, Weight: 85}}},
+		{"nan cadence", Running{Training: base, Cadence: nan}},
+		{"profile age", Running{Training: Training{Action: 5000, LenStep: LenStep, Duration: time.Hour, Weight: 85, Profile: &UserProfile{Age: 200}}}},
+		{"profile nan heart rate", Swimming{Training: Training{Weight: 85, Profile: &UserProfile{RestingHR: nan}}}},
+		{"negative meters", Rowing{Training: base, Meters: -1}},
+	}
+	for _, tt := range tests {
+		if err := tt.training.Validate(); err == nil {
+			t.Errorf("%s: Validate() = nil, want error (calories %v)", tt.name, tt.training.Calories())
+		}
+	}
+}
+
+// cycling пользовательская тренировка, которая встраивает Training и переопределяет только Calories.
+type cycling struct {
+	Training
+}
+
+func (c cycling) Calories() float64 {
+	return 100
+}
+
+func TestReadDataCustomCalculator(t *testing.T) {
+	ride := cycling{Training{TrainingType: "Велосипед", Action: 1000, LenStep: 5, Duration: time.Hour, Weight: 70}}
+	if got := readInfo(ride).Calories; got != 100 {
+		t.Errorf("readInfo().Calories = %v, want 100", got)
+	}
+	if text := ReadData(ride); !strings.Contains(text, "Потрачено ккал: 100.00") {
+		t.Errorf("ReadData() = %q, want calories from Calories()", text)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// MultiSportTrainingType тип тренировки по умолчанию для MultiSport.
+const MultiSportTrainingType = "Мультиспорт"
+
+// MultiSport составная тренировка из нескольких этапов подряд, например плавание, велосипед и бег в триатлоне.
+type MultiSport struct {
+	TrainingType string               // тип тренировки, пустое значение - MultiSportTrainingType
+	StartedAt    time.Time            // время начала, пустое значение - время начала первого этапа
+	Segments     []CaloriesCalculator // этапы в порядке выполнения
+	Transitions  []time.Duration      // время транзитных зон между этапами, по одной на промежуток
+}
+
+// Validate проверяет, что количество транзитов соответствует количеству этапов, транзиты
+// не отрицательные, а этапы, у которых есть метод Validate, проходят свою проверку.
+func (m MultiSport) Validate() error {
+	if len(m.Segments) == 0 {
+		return fmt.Errorf("в составной тренировке нет этапов")
+	}
+	if len(m.Transitions) > len(m.Segments)-1 {
+		return fmt.Errorf("транзитов %d, а промежутков между этапами %d", len(m.Transitions), len(m.Segments)-1)
+	}
+	for i, transition := range m.Transitions {
+		if transition < 0 {
+			return fmt.Errorf("транзит %d не может быть отрицательным, получено %v", i+1, transition)
+		}
+	}
+	for i, segment := range m.Segments {
+		v, ok := segment.(interface{ Validate() error })
+		if !ok {
+			continue
+		}
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("этап %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// SegmentsInfo возвращает информацию о каждом этапе с посчитанными калориями.
+func (m MultiSport) SegmentsInfo() []InfoMessage {
+	infos := make([]InfoMessage, 0, len(m.Segments))
+	for _, segment := range m.Segments {
+		infos = append(infos, readInfo(segment))
+	}
+	return infos
+}
+
+// Calories возвращает сумму калорий по всем этапам. Калории в транзитных зонах не учитываются.
+func (m MultiSport) Calories() float64 {
+	var calories float64
+	for _, segment := range m.Segments {
+		calories += segment.Calories()
+	}
+	return calories
+}
+
+// MultiSportInfo информация о составной тренировке: итог и этапы.
+// Этапы хранятся отдельно от InfoMessage, чтобы InfoMessage оставался сравнимым через ==.
+type MultiSportInfo struct {
+	InfoMessage               // итог по всем этапам
+	Segments    []InfoMessage // информация по этапам в порядке выполнения
+}
+
+// Info возвращает итог тренировки вместе с информацией по этапам, посчитав каждый этап один раз:
+// дистанция и калории суммируются по этапам, длительность включает транзиты,
+// а средняя скорость считается по общей дистанции и длительности.
+func (m MultiSport) Info() MultiSportInfo {
+	info := MultiSportInfo{
+		InfoMessage: InfoMessage{
+			TrainingType: m.TrainingType,
+			StartedAt:    m.StartedAt,
+		},
+		Segments: m.SegmentsInfo(),
+	}
+	if info.TrainingType == "" {
+		info.TrainingType = MultiSportTrainingType
+	}
+	if info.StartedAt.IsZero() && len(info.Segments) > 0 {
+		info.StartedAt = info.Segments[0].StartedAt
+	}
+
+	for _, segment := range info.Segments {
+		info.Duration += segment.Duration
+		info.Distance += segment.Distance
+		info.Calories += segment.Calories
+	}
+	for _, transition := range m.Transitions {
+		info.Duration += transition
+	}
+	info.Speed = speedKmh(info.Distance, info.Duration)
+	return info
+}
+
+// String возвращает итог тренировки и информацию по каждому этапу.
+func (m MultiSportInfo) String() string {
+	return string(DefaultDisplayFormat.appendSegments(AppendInfo(nil, m.InfoMessage), m.Segments))
+}
+
+// TrainingInfo возвращает общую информацию о тренировке без информации по этапам, ее возвращает Info.
+func (m MultiSport) TrainingInfo() InfoMessage {
+	return m.Info().InfoMessage
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMultiSportValidate(t *testing.T) {
+	swim := Swimming{Training: Training{TrainingType: "Плавание", Action: 1100, LenStep: SwimmingLenStep, Duration: 30 * time.Minute, Weight: 70},
+		LengthPool: 25, CountPool: 60}
+	run := Running{Training: Training{TrainingType: "Бег", Action: 8000, LenStep: LenStep, Duration: 45 * time.Minute, Weight: 70}}
+	invalidRun := run
+	invalidRun.Weight = -70
+
+	tests := []struct {
+		name    string
+		brick   MultiSport
+		wantErr bool
+	}{
+		{"valid", MultiSport{Segments: []CaloriesCalculator{swim, run}, Transitions: []time.Duration{3 * time.Minute}}, false},
+		{"no transitions", MultiSport{Segments: []CaloriesCalculator{swim, run}}, false},
+		{"no segments", MultiSport{}, true},
+		{"too many transitions", MultiSport{Segments: []CaloriesCalculator{run}, Transitions: []time.Duration{time.Minute}}, true},
+		{"negative transition", MultiSport{Segments: []CaloriesCalculator{swim, run}, Transitions: []time.Duration{-2 * time.Hour}}, true},
+		{"invalid segment", MultiSport{Segments: []CaloriesCalculator{swim, invalidRun}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.brick.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
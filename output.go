@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format формат, в котором выводится информация о тренировке.
+type Format int
+
+// Поддерживаемые форматы вывода.
+const (
+	FormatText Format = iota // текст, как в ReadData, для составной тренировки итог и этапы
+	FormatJSON               // JSON-объект
+	FormatCSV                // строки CSV, колонки как в CSVHeader
+)
+
+// CSVHeader названия колонок строк, которые выводит FormatCSV.
+// Для составной тренировки первая строка содержит итог, а следующие - этапы с номером в колонке segment.
+// В колонке estimated через запятую перечислены поля, которые оценены, а не измерены, как в JSON.
+var CSVHeader = []string{"training_type", "duration_min", "distance_km", "speed_kmh", "calories", "started_at", "segment", "estimated"}
+
+// infoJSON представление InfoMessage в JSON.
+type infoJSON struct {
+	TrainingType string     `json:"training_type"`
+	DurationMin  float64    `json:"duration_min"`
+	DistanceKm   float64    `json:"distance_km"`
+	SpeedKmh     float64    `json:"speed_kmh"`
+	Calories     float64    `json:"calories"`
+	StartedAt    string     `json:"started_at,omitempty"`
+	Cadence      float64    `json:"cadence,omitempty"`
+	StrideLength float64    `json:"stride_length_m,omitempty"`
+	FluidLoss    float64    `json:"fluid_loss_ml,omitempty"`
+	Carbs        float64    `json:"carbs_g,omitempty"`
+	Segments     []infoJSON `json:"segments,omitempty"`
+	Estimated    []string   `json:"estimated,omitempty"`
+}
+
+// newInfoJSON возвращает представление info в JSON вместе с этапами segments.
+// Числа округляются с точностью DefaultDisplayFormat, а NaN и бесконечности заменяются на 0, как в тексте.
+func newInfoJSON(info InfoMessage, segments []InfoMessage) infoJSON {
+	f := DefaultDisplayFormat
+	j := infoJSON{
+		TrainingType: info.TrainingType,
+		DurationMin:  f.Round(info.Duration.Minutes(), f.DurationPrecision),
+		DistanceKm:   f.Round(info.Distance, f.DistancePrecision),
+		SpeedKmh:     f.Round(info.Speed, f.SpeedPrecision),
+		Calories:     f.Round(info.Calories, f.CaloriesPrecision),
+		StartedAt:    formatStartedAt(info),
+		Cadence:      f.Round(info.Cadence, -1),
+		StrideLength: f.Round(info.StrideLength, -1),
+		FluidLoss:    f.Round(info.Fueling.FluidLoss, f.FuelingPrecision),
+		Carbs:        f.Round(info.Fueling.Carbs, f.FuelingPrecision),
+		Estimated:    info.Estimated.Names(),
+	}
+	for _, segment := range segments {
+		j.Segments = append(j.Segments, newInfoJSON(segment, nil))
+	}
+	return j
+}
+
+// csvRecord возвращает строку CSV для info с номером этапа segment, пустым для итоговой строки.
+// Числа выводятся с точностью DefaultDisplayFormat, но всегда с точкой, чтобы их можно было разобрать.
+func csvRecord(info InfoMessage, segment string) []string {
+	f := DefaultDisplayFormat
+	f.DecimalSeparator = "."
+	return []string{
+		info.TrainingType,
+		f.Number(info.Duration.Minutes(), f.DurationPrecision),
+		f.Number(info.Distance, f.DistancePrecision),
+		f.Number(info.Speed, f.SpeedPrecision),
+		f.Number(info.Calories, f.CaloriesPrecision),
+		formatStartedAt(info),
+		segment,
+		info.Estimated.String(),
+	}
+}
+
+// String возвращает название формата.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	case FormatCSV:
+		return "csv"
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ReadDataAs возвращает информацию о проведенной тренировке в заданном формате.
+func ReadDataAs(training CaloriesCalculator, format Format) (string, error) {
+	var sb strings.Builder
+	if err := WriteInfo(&sb, training, format); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// WriteInfo записывает информацию о проведенной тренировке в w в заданном формате.
+func WriteInfo(w io.Writer, training CaloriesCalculator, format Format) error {
+	info, segments := readSegmentsInfo(training)
+
+	switch format {
+	case FormatText:
+		bp := infoBufPool.Get().(*[]byte)
+		b := AppendInfo((*bp)[:0], info)
+		b = DefaultDisplayFormat.appendSegments(b, segments)
+		_, err := w.Write(b)
+		*bp = b
+		infoBufPool.Put(bp)
+		return err
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(newInfoJSON(info, segments))
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvRecord(info, "")); err != nil {
+			return err
+		}
+		for i, segment := range segments {
+			if err := cw.Write(csvRecord(segment, strconv.Itoa(i+1))); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("неизвестный формат вывода: %v", format)
+}
+
+// readSegmentsInfo возвращает информацию о тренировке и, для составной тренировки, информацию по этапам.
+func readSegmentsInfo(training CaloriesCalculator) (InfoMessage, []InfoMessage) {
+	if m, ok := training.(MultiSport); ok {
+		info := m.Info()
+		return info.InfoMessage, info.Segments
+	}
+	return readInfo(training), nil
+}
+
+// formatStartedAt возвращает время начала тренировки в RFC 3339 со смещением часового пояса
+// или пустую строку, если время не задано.
+func formatStartedAt(info InfoMessage) string {
+	if info.StartedAt.IsZero() {
+		return ""
+	}
+	return info.StartedAt.Format(time.RFC3339)
+}
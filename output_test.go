@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteInfoFueling(t *testing.T) {
+	running := Running{
+		Training:    Training{TrainingType: "Бег", Action: 15000, LenStep: LenStep, Duration: 90 * time.Minute, Weight: 70},
+		Environment: &Environment{Temperature: 28},
+	}
+	want := EstimateFueling(running.TrainingInfo(), 28)
+	if want.IsZero() {
+		t.Fatal("EstimateFueling() returned zero estimate")
+	}
+
+	text, err := ReadDataAs(running, FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "Потеря жидкости: ") || !strings.Contains(text, "Углеводы: ") {
+		t.Errorf("FormatText has no fueling lines:\n%s", text)
+	}
+
+	data, err := ReadDataAs(running, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		FluidLoss float64 `json:"fluid_loss_ml"`
+		Carbs     float64 `json:"carbs_g"`
+	}
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.FluidLoss == 0 || got.Carbs == 0 {
+		t.Errorf("FormatJSON fueling = %+v, want non-zero %+v", got, want)
+	}
+
+	running.Environment = nil
+	if text := ReadData(running); strings.Contains(text, "Потеря жидкости") {
+		t.Errorf("ReadData() without environment has fueling lines:\n%s", text)
+	}
+}
+
+func TestWriteInfoSanitizesValues(t *testing.T) {
+	// вес NaN не проходит Validate, но вывод не должен из-за него ломаться
+	running := Running{Training: Training{TrainingType: "Бег", Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: math.NaN()}}
+
+	data, err := ReadDataAs(running, FormatJSON)
+	if err != nil {
+		t.Fatalf("FormatJSON: %v", err)
+	}
+	var got struct {
+		SpeedKmh float64 `json:"speed_kmh"`
+		Calories float64 `json:"calories"`
+	}
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Calories != 0 || got.SpeedKmh != 6.5 {
+		t.Errorf("FormatJSON = %s, want calories 0 and speed 6.5", data)
+	}
+
+	running.Weight = 85
+	running.Duration = 31 * time.Minute
+	text, err := ReadDataAs(running, FormatCSV)
+	if err != nil {
+		t.Fatalf("FormatCSV: %v", err)
+	}
+	if want := "Бег,31,3.25,6.29,303.07,,,\n"; text != want {
+		t.Errorf("FormatCSV = %q, want %q", text, want)
+	}
+
+	running.Weight = math.NaN()
+	if text, _ := ReadDataAs(running, FormatCSV); strings.Contains(text, "NaN") {
+		t.Errorf("FormatCSV = %q, want no NaN", text)
+	}
+}
+
+func TestWriteInfoMultiSportSegments(t *testing.T) {
+	brick := MultiSport{
+		Segments: []CaloriesCalculator{
+			Swimming{Training: Training{TrainingType: "Плавание", Action: 1100, LenStep: SwimmingLenStep, Duration: 30 * time.Minute, Weight: 70},
+				LengthPool: 25, CountPool: 60},
+			Running{Training: Training{TrainingType: "Бег", Action: 8000, LenStep: LenStep, Duration: 45 * time.Minute, Weight: 70}},
+		},
+		Transitions: []time.Duration{3 * time.Minute},
+	}
+
+	data, err := ReadDataAs(brick, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		TrainingType string `json:"training_type"`
+		Segments     []struct {
+			TrainingType string `json:"training_type"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TrainingType != MultiSportTrainingType || len(got.Segments) != 2 || got.Segments[1].TrainingType != "Бег" {
+		t.Errorf("FormatJSON = %s, want total with two segments", data)
+	}
+
+	text, err := ReadDataAs(brick, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(text), "\n"); len(lines) != 3 || !strings.HasSuffix(lines[2], ",2,") {
+		t.Errorf("FormatCSV = %q, want total and two segment rows", text)
+	}
+
+	info := brick.Info()
+	want := info.InfoMessage.String() + "\nЭтап 1:\n" + info.Segments[0].String() + "\nЭтап 2:\n" + info.Segments[1].String()
+	if text, err := ReadDataAs(brick, FormatText); err != nil || text != want {
+		t.Errorf("FormatText = %q, %v, want %q", text, err, want)
+	}
+	if text := ReadData(brick); text != want {
+		t.Errorf("ReadData() = %q, want %q", text, want)
+	}
+}
+
+func TestWriteInfoNegativeZero(t *testing.T) {
+	// вес -0 проходит Validate, а калории получаются -0
+	running := Running{Training: Training{TrainingType: "Бег", Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: math.Copysign(0, -1)}}
+	if err := running.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []Format{FormatText, FormatJSON, FormatCSV} {
+		text, err := ReadDataAs(running, format)
+		if err != nil {
+			t.Fatalf("%v: %v", format, err)
+		}
+		if strings.Contains(text, "-0") {
+			t.Errorf("%v = %q, want no -0", format, text)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PlannedSession запланированная тренировка.
+type PlannedSession struct {
+	TrainingType string        // тип тренировки, как в Training.TrainingType
+	Duration     time.Duration // целевая продолжительность, 0 - не задана
+	Distance     float64       // целевая дистанция в км, 0 - не задана
+}
+
+// WeekPlan план тренировок на неделю.
+type WeekPlan struct {
+	Start    time.Time        // первый день недели, время внутри дня не учитывается
+	Sessions []PlannedSession // запланированные тренировки
+}
+
+// TypeCompliance выполнение плана по одному типу тренировок.
+type TypeCompliance struct {
+	TrainingType    string        // тип тренировки
+	Planned         int           // количество запланированных тренировок
+	Completed       int           // количество выполненных тренировок, не больше Planned
+	PlannedDuration time.Duration // запланированная продолжительность
+	ActualDuration  time.Duration // продолжительность выполненных тренировок
+	PlannedDistance float64       // запланированная дистанция в км
+	ActualDistance  float64       // дистанция выполненных тренировок в км
+}
+
+// VolumePercent возвращает выполненный объем в процентах от запланированного.
+// Объем считается по дистанции, если она запланирована, иначе по продолжительности.
+func (c TypeCompliance) VolumePercent() float64 {
+	switch {
+	case c.PlannedDistance > 0:
+		return c.ActualDistance / c.PlannedDistance * 100
+	case c.PlannedDuration > 0:
+		return float64(c.ActualDuration) / float64(c.PlannedDuration) * 100
+	}
+	return 0
+}
+
+// String возвращает строку с выполнением плана по типу тренировок.
+func (c TypeCompliance) String() string {
+	return fmt.Sprintf("%s: выполнено %d из %d запланированных тренировок, %.0f%% запланированного объема",
+		c.TrainingType, c.Completed, c.Planned, c.VolumePercent())
+}
+
+// ComplianceReport выполнение недельного плана.
+type ComplianceReport struct {
+	Week  time.Time        // первый день недели
+	Types []TypeCompliance // выполнение по типам в порядке первого появления в плане
+}
+
+// String возвращает отчет о выполнении плана, по строке на тип тренировок.
+func (r ComplianceReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Неделя с %s\n", r.Week.Format("02.01.2006"))
+	for _, c := range r.Types {
+		sb.WriteString(c.String() + "\n")
+	}
+	return sb.String()
+}
+
+// Compare сравнивает план с проведенными тренировками. Учитываются тренировки запланированных типов,
+// которые начались в течение семи календарных дней с начала недели; тренировки без времени начала пропускаются.
+func (p WeekPlan) Compare(actual []InfoMessage) ComplianceReport {
+	start := CalendarDay(p.Start)
+	end := start.AddDate(0, 0, 7)
+	report := ComplianceReport{Week: start}
+
+	index := make(map[string]int)
+	for _, session := range p.Sessions {
+		i, ok := index[session.TrainingType]
+		if !ok {
+			i = len(report.Types)
+			index[session.TrainingType] = i
+			report.Types = append(report.Types, TypeCompliance{TrainingType: session.TrainingType})
+		}
+		c := &report.Types[i]
+		c.Planned++
+		c.PlannedDuration += session.Duration
+		c.PlannedDistance += session.Distance
+	}
+
+	for _, info := range actual {
+		i, ok := index[info.TrainingType]
+		if !ok || info.StartedAt.IsZero() {
+			continue
+		}
+		started := info.StartedAt.In(start.Location())
+		if started.Before(start) || !started.Before(end) {
+			continue
+		}
+		c := &report.Types[i]
+		if c.Completed < c.Planned {
+			c.Completed++
+		}
+		c.ActualDuration += info.Duration
+		c.ActualDistance += info.Distance
+	}
+	return report
+}
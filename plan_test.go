@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWeekPlanCompare(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	plan := WeekPlan{
+		// время внутри дня не учитывается, неделя начинается 4 мая в 00:00 по Москве, то есть 3 мая в 21:00 UTC
+		Start: time.Date(2026, 5, 4, 15, 0, 0, 0, msk),
+		Sessions: []PlannedSession{
+			{TrainingType: "Бег", Distance: 10},
+			{TrainingType: "Плавание", Duration: time.Hour},
+			{TrainingType: "Бег", Distance: 5, Duration: 30 * time.Minute},
+		},
+	}
+	utc := func(day, hour, min int) time.Time { return time.Date(2026, 5, day, hour, min, 0, 0, time.UTC) }
+	run := func(started time.Time) InfoMessage {
+		return InfoMessage{TrainingType: "Бег", StartedAt: started, Duration: 40 * time.Minute, Distance: 6}
+	}
+	actual := []InfoMessage{
+		run(utc(3, 20, 59)), // до начала недели по Москве
+		run(utc(3, 21, 0)),  // первая минута недели
+		run(utc(7, 6, 0)),
+		run(utc(10, 20, 59)), // последняя минута недели
+		run(utc(10, 21, 0)),  // уже следующая неделя
+		{TrainingType: "Бег", Duration: time.Hour, Distance: 12},                            // без времени начала
+		{TrainingType: "Ходьба", StartedAt: utc(5, 8, 0), Duration: time.Hour, Distance: 5}, // не запланирована
+		{TrainingType: "Плавание", StartedAt: utc(6, 5, 0), Duration: 45 * time.Minute, Distance: 1.5},
+	}
+
+	report := plan.Compare(actual)
+	if want := time.Date(2026, 5, 4, 0, 0, 0, 0, msk); !report.Week.Equal(want) {
+		t.Errorf("Week = %v, want %v", report.Week, want)
+	}
+	if len(report.Types) != 2 {
+		t.Fatalf("Types = %+v, want running and swimming in plan order", report.Types)
+	}
+
+	running := report.Types[0]
+	if running.TrainingType != "Бег" || running.Planned != 2 || running.Completed != 2 {
+		t.Errorf("running = %+v, want 2 of 2 completed, capped at planned", running)
+	}
+	if running.ActualDistance != 18 || running.ActualDuration != 2*time.Hour {
+		t.Errorf("running actual = %v km, %v, want 3 runs of the week", running.ActualDistance, running.ActualDuration)
+	}
+	// объем по дистанции, хотя у одной тренировки задана и продолжительность
+	if got := running.VolumePercent(); math.Abs(got-120) > 1e-9 {
+		t.Errorf("running VolumePercent() = %v, want 120 by distance", got)
+	}
+
+	swimming := report.Types[1]
+	if swimming.Planned != 1 || swimming.Completed != 1 {
+		t.Errorf("swimming = %+v, want 1 of 1 completed", swimming)
+	}
+	if got := swimming.VolumePercent(); math.Abs(got-75) > 1e-9 {
+		t.Errorf("swimming VolumePercent() = %v, want 75 by duration", got)
+	}
+
+	if got := (TypeCompliance{}).VolumePercent(); got != 0 {
+		t.Errorf("VolumePercent() without planned volume = %v, want 0", got)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import "fmt"
+
+// UserProfile данные пользователя, которые не относятся к отдельной тренировке.
+type UserProfile struct {
+	Age       int     // возраст в годах
+	RestingHR float64 // пульс в покое в уд/мин, 0 - не задан
+	MaxHR     float64 // максимальный пульс в уд/мин, 0 - оценивается по возрасту
+	Weight    float64 // вес в кг, 0 - не задан
+	Height    float64 // рост в см, 0 - не задан
+}
+
+// Константы для оценки максимального пульса по возрасту (формула Танаки).
+const (
+	MaxHRBase          = 208 // максимальный пульс при нулевом возрасте
+	MaxHRAgeMultiplier = 0.7 // снижение максимального пульса за год
+)
+
+// maxHR возвращает максимальный пульс пользователя.
+// Если он не задан, оценивается по формуле:
+// 208 - 0.7 * возраст
+func (p UserProfile) maxHR() float64 {
+	if p.MaxHR > 0 {
+		return p.MaxHR
+	}
+	if p.Age <= 0 {
+		return 0
+	}
+	return MaxHRBase - MaxHRAgeMultiplier*float64(p.Age)
+}
+
+// heartRateReserve возвращает долю резерва пульса для среднего пульса avg
+// или 0, если пульс в покое или максимальный пульс неизвестен:
+// (пульс - пульс_в_покое) / (макс_пульс - пульс_в_покое)
+func (p UserProfile) heartRateReserve(avg float64) float64 {
+	maxHR := p.maxHR()
+	if !(avg > p.RestingHR && p.RestingHR > 0 && maxHR > avg) {
+		return 0
+	}
+	return (avg - p.RestingHR) / (maxHR - p.RestingHR)
+}
+
+// AgeBand возрастная группа, для которой корректируются формулы калорий.
+type AgeBand string
+
+// Возрастные группы.
+const (
+	AgeBandChild  AgeBand = "child"  // от MinProfileAge до AdultAge лет
+	AgeBandAdult  AgeBand = "adult"  // от AdultAge до SeniorAge лет
+	AgeBandSenior AgeBand = "senior" // от SeniorAge лет
+)
+
+// Границы возрастных групп и допустимого возраста в годах.
+const (
+	MinProfileAge = 6   // возраст, с которого формулы калорий применимы
+	AdultAge      = 18  // возраст, с которого пользователь считается взрослым
+	SeniorAge     = 65  // возраст, с которого пользователь считается пожилым
+	MaxProfileAge = 110 // наибольший допустимый возраст
+)
+
+// AgeBandCaloriesMultiplier множитель калорий для возрастной группы относительно взрослого.
+// Дети тратят больше энергии на килограмм веса при той же скорости, у пожилых ниже основной обмен.
+var AgeBandCaloriesMultiplier = map[AgeBand]float64{
+	AgeBandChild:  1.15,
+	AgeBandAdult:  1,
+	AgeBandSenior: 0.9,
+}
+
+// Validate проверяет, что данные профиля допустимы.
+// Возраст 0 означает, что он не задан.
+func (p UserProfile) Validate() error {
+	if p.Age != 0 && (p.Age < MinProfileAge || p.Age > MaxProfileAge) {
+		return fmt.Errorf("возраст должен быть от %d до %d лет, получено %d", MinProfileAge, MaxProfileAge, p.Age)
+	}
+	if !isNonNegative(p.RestingHR) || !isNonNegative(p.MaxHR) {
+		return fmt.Errorf("пульс должен быть неотрицательным числом, получено %v и %v", p.RestingHR, p.MaxHR)
+	}
+	if !isNonNegative(p.Weight) || !isNonNegative(p.Height) {
+		return fmt.Errorf("вес и рост должны быть неотрицательными числами, получено %v и %v", p.Weight, p.Height)
+	}
+	if p.RestingHR > 0 && p.MaxHR > 0 && p.RestingHR >= p.MaxHR {
+		return fmt.Errorf("пульс в покое %v должен быть меньше максимального %v", p.RestingHR, p.MaxHR)
+	}
+	return nil
+}
+
+// AgeBand возвращает возрастную группу пользователя. Если возраст не задан, пользователь считается взрослым.
+func (p UserProfile) AgeBand() AgeBand {
+	switch {
+	case p.Age == 0:
+		return AgeBandAdult
+	case p.Age < AdultAge:
+		return AgeBandChild
+	case p.Age >= SeniorAge:
+		return AgeBandSenior
+	}
+	return AgeBandAdult
+}
+
+// caloriesMultiplier возвращает множитель калорий для возрастной группы пользователя.
+// Для nil профиля поправки нет.
+func (p *UserProfile) caloriesMultiplier() float64 {
+	if p == nil {
+		return 1
+	}
+	return AgeBandCaloriesMultiplier[p.AgeBand()]
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Project возвращает ожидаемую информацию о тренировке, которая еще идет, если пользователь
+// сохранит текущий темп до запланированной продолжительности planned.
+// Повторы (шаги, гребки, пересечения бассейна) и дистанция по монитору тренажера масштабируются
+// пропорционально времени, а темп гребли сохраняется.
+// Поддерживаются Running, Walking, Swimming и Rowing; продолжительность тренировок из серий задана заранее.
+func Project(training CaloriesCalculator, planned time.Duration) (InfoMessage, error) {
+	current := training.TrainingInfo()
+	if current.Duration <= 0 {
+		return InfoMessage{}, fmt.Errorf("прогноз невозможен для тренировки нулевой продолжительности")
+	}
+	if planned < current.Duration {
+		return InfoMessage{}, fmt.Errorf("запланированная продолжительность %v меньше прошедшей %v", planned, current.Duration)
+	}
+
+	switch t := training.(type) {
+	case Running:
+		return readInfo(t.project(planned)), nil
+	case Walking:
+		return readInfo(t.project(planned)), nil
+	case Swimming:
+		return readInfo(t.project(planned)), nil
+	case Rowing:
+		return readInfo(t.project(planned)), nil
+	}
+	return InfoMessage{}, fmt.Errorf("прогноз не поддерживается для тренировки %T", training)
+}
+
+// project возвращает копию тренировки, продленную до planned с тем же темпом.
+func (t Training) project(planned time.Duration) Training {
+	factor := float64(planned) / float64(t.Duration)
+	t.Action = int(math.Round(float64(t.Action) * factor))
+	t.Duration = planned
+	return t
+}
+
+// project возвращает копию тренировки Бег, продленную до planned с тем же темпом.
+func (r Running) project(planned time.Duration) Running {
+	factor := float64(planned) / float64(r.Duration)
+	r.TreadmillDistance *= factor
+	r.MeasuredDistance *= factor
+	r.Training = r.Training.project(planned)
+	return r
+}
+
+// project возвращает копию тренировки Ходьба, продленную до planned с тем же темпом.
+func (w Walking) project(planned time.Duration) Walking {
+	w.Training = w.Training.project(planned)
+	return w
+}
+
+// project возвращает копию тренировки Плавание, продленную до planned с тем же темпом.
+func (s Swimming) project(planned time.Duration) Swimming {
+	s.CountPool = int(math.Round(float64(s.CountPool) * float64(planned) / float64(s.Duration)))
+	s.Training = s.Training.project(planned)
+	return s
+}
+
+// project возвращает копию тренировки на гребном тренажере, продленную до planned с тем же темпом.
+func (r Rowing) project(planned time.Duration) Rowing {
+	r.Meters = int(math.Round(float64(r.Meters) * float64(planned) / float64(r.Duration)))
+	r.Training = r.Training.project(planned)
+	return r
+}
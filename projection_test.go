@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestProject(t *testing.T) {
+	base := Training{TrainingType: "Бег", Action: 3000, LenStep: LenStep, Duration: 20 * time.Minute, Weight: 75}
+	long := base
+	long.Action, long.Duration = 6000, 40*time.Minute
+
+	noSteps := func(tr Training) Training { tr.Action = 0; return tr }
+	swim := Training{TrainingType: "Плавание", Action: 400, LenStep: SwimmingLenStep, Duration: 30 * time.Minute, Weight: 70}
+	swimLong := swim
+	swimLong.Action, swimLong.Duration = 600, 45*time.Minute
+
+	tests := []struct {
+		name     string
+		training CaloriesCalculator
+		planned  time.Duration
+		want     CaloriesCalculator
+	}{
+		{"running", Running{Training: base}, 40 * time.Minute, Running{Training: long}},
+		{"running cadence", Running{Training: noSteps(base), Cadence: 170}, 40 * time.Minute, Running{Training: noSteps(long), Cadence: 170}},
+		{"running treadmill", Running{Training: base, TreadmillDistance: 4}, 40 * time.Minute, Running{Training: long, TreadmillDistance: 8}},
+		{"running measured", Running{Training: base, MeasuredDistance: 3.5}, 40 * time.Minute, Running{Training: long, MeasuredDistance: 7}},
+		{"running same duration", Running{Training: base}, 20 * time.Minute, Running{Training: base}},
+		{"walking", Walking{Training: base, Height: 180, Poles: true}, 40 * time.Minute, Walking{Training: long, Height: 180, Poles: true}},
+		{"swimming", Swimming{Training: swim, LengthPool: 25, CountPool: 40}, 45 * time.Minute, Swimming{Training: swimLong, LengthPool: 25, CountPool: 60}},
+	}
+	for _, tt := range tests {
+		got, err := Project(tt.training, tt.planned)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want := tt.want.TrainingInfo()
+		if got.TrainingType != want.TrainingType || got.Duration != want.Duration ||
+			math.Abs(got.Distance-want.Distance) > 1e-9 || math.Abs(got.Speed-want.Speed) > 1e-9 ||
+			math.Abs(got.Calories-want.Calories) > 1e-9 {
+			t.Errorf("%s: Project() = %+v, want %+v", tt.name, got, want)
+		}
+	}
+}
+
+func TestProjectErrors(t *testing.T) {
+	running := Running{Training: Training{TrainingType: "Бег", Action: 3000, LenStep: LenStep, Duration: 20 * time.Minute, Weight: 75}}
+	idle := running
+	idle.Duration = 0
+	swims, err := NewSwimWorkout("4x100 @2:00", 70, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		training CaloriesCalculator
+		planned  time.Duration
+	}{
+		{"zero elapsed", idle, time.Hour},
+		{"planned before elapsed", running, 10 * time.Minute},
+		{"unsupported type", swims, time.Hour},
+	}
+	for _, tt := range tests {
+		if got, err := Project(tt.training, tt.planned); err == nil {
+			t.Errorf("%s: Project() = %+v, want error", tt.name, got)
+		}
+	}
+}
+
+func TestProjectRowing(t *testing.T) {
+	rowing := Rowing{Training: Training{TrainingType: "Гребля", Duration: 10 * time.Minute, Weight: 80}, Meters: 2500}
+
+	got, err := Project(rowing, 20*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Rowing{Training: Training{TrainingType: "Гребля", Duration: 20 * time.Minute, Weight: 80}, Meters: 5000}.TrainingInfo()
+	if got.Duration != want.Duration || math.Abs(got.Distance-want.Distance) > 1e-9 || math.Abs(got.Calories-want.Calories) > 1e-9 {
+		t.Errorf("Project() = %+v, want %+v", got, want)
+	}
+}
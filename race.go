@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// RiegelExponent показатель степени в формуле Ригеля.
+const RiegelExponent = 1.06
+
+// RaceDistance стандартная беговая дистанция.
+type RaceDistance struct {
+	Name     string  // название дистанции
+	Distance float64 // дистанция в км
+}
+
+// StandardRaceDistances дистанции, для которых RacePredictor.Predictions строит прогноз.
+var StandardRaceDistances = []RaceDistance{
+	{Name: "5 км", Distance: 5},
+	{Name: "10 км", Distance: 10},
+	{Name: "Полумарафон", Distance: 21.0975},
+	{Name: "Марафон", Distance: 42.195},
+}
+
+// RacePrediction прогноз времени на дистанции.
+type RacePrediction struct {
+	RaceDistance
+	Time time.Duration // прогнозируемое время
+}
+
+// RacePredictor прогнозирует время на дистанциях по одному лучшему результату.
+type RacePredictor struct {
+	Distance float64       // дистанция лучшего результата в км
+	Time     time.Duration // время лучшего результата
+}
+
+// NewRacePredictor выбирает среди пробежек лучший результат и возвращает прогноз по нему.
+// Результаты на разных дистанциях сравниваются по прогнозу на 10 км, поэтому быстрая пятерка
+// и медленный полумарафон сравниваются честно.
+func NewRacePredictor(runs []Running) (RacePredictor, error) {
+	var best RacePredictor
+	var bestTime time.Duration
+	for _, r := range runs {
+		p := RacePredictor{Distance: r.distance(), Time: r.Duration}
+		if p.Distance <= 0 || p.Time <= 0 {
+			continue
+		}
+		if t := p.PredictTime(10); bestTime == 0 || t < bestTime {
+			best, bestTime = p, t
+		}
+	}
+	if bestTime == 0 {
+		return RacePredictor{}, errors.New("нет пробежек с ненулевыми дистанцией и продолжительностью")
+	}
+	return best, nil
+}
+
+// PredictTime возвращает прогноз времени на дистанции distanceKm по формуле Ригеля:
+// время_результата * (дистанция / дистанция_результата)**1.06
+func (p RacePredictor) PredictTime(distanceKm float64) time.Duration {
+	if p.Distance <= 0 || distanceKm <= 0 {
+		return 0
+	}
+	t := float64(p.Time) * math.Pow(distanceKm/p.Distance, RiegelExponent)
+	return time.Duration(t).Round(time.Second)
+}
+
+// Predictions возвращает прогнозы для StandardRaceDistances.
+func (p RacePredictor) Predictions() []RacePrediction {
+	predictions := make([]RacePrediction, 0, len(StandardRaceDistances))
+	for _, d := range StandardRaceDistances {
+		predictions = append(predictions, RacePrediction{RaceDistance: d, Time: p.PredictTime(d.Distance)})
+	}
+	return predictions
+}
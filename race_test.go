@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// raceRun пробежка на дистанции distance км за время d.
+func raceRun(distance float64, d time.Duration) Running {
+	return Running{Training: Training{TrainingType: "Бег", Duration: d, Weight: 70}, MeasuredDistance: distance}
+}
+
+func TestRacePredictorPredictTime(t *testing.T) {
+	p := RacePredictor{Distance: 5, Time: 20 * time.Minute}
+	tests := []struct {
+		distance float64
+		want     time.Duration
+	}{
+		{5, 20 * time.Minute},
+		// 20:00 * 2^1.06 = 41:41.9
+		{10, 41*time.Minute + 42*time.Second},
+		{42.195, 3*time.Hour + 11*time.Minute + 49*time.Second},
+		{0, 0},
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		if got := p.PredictTime(tt.distance); got != tt.want {
+			t.Errorf("PredictTime(%v) = %v, want %v", tt.distance, got, tt.want)
+		}
+	}
+	if got := (RacePredictor{}).PredictTime(10); got != 0 {
+		t.Errorf("PredictTime() without result = %v, want 0", got)
+	}
+
+	predictions := p.Predictions()
+	if len(predictions) != len(StandardRaceDistances) || predictions[1].Name != "10 км" || predictions[1].Time != 41*time.Minute+42*time.Second {
+		t.Errorf("Predictions() = %+v, want 10 km in 41:42", predictions)
+	}
+}
+
+func TestNewRacePredictor(t *testing.T) {
+	fiveK := raceRun(5, 20*time.Minute)
+	slowTenK := raceRun(10, 50*time.Minute)
+	// 1:40:00 на полумарафоне дает 45:19 на 10 км, медленнее пятерки за 20:00 (41:42)
+	slowHalf := raceRun(21.0975, 100*time.Minute)
+	// 1:30:00 на полумарафоне дает 40:47 на 10 км, быстрее пятерки
+	fastHalf := raceRun(21.0975, 90*time.Minute)
+
+	tests := []struct {
+		name string
+		runs []Running
+		want RacePredictor
+	}{
+		{"fast 5 km", []Running{slowTenK, fiveK, slowHalf}, RacePredictor{Distance: 5, Time: 20 * time.Minute}},
+		{"fast half marathon", []Running{fiveK, fastHalf, slowTenK}, RacePredictor{Distance: 21.0975, Time: 90 * time.Minute}},
+		{"skips empty runs", []Running{raceRun(0, time.Hour), slowTenK, raceRun(5, 0)}, RacePredictor{Distance: 10, Time: 50 * time.Minute}},
+	}
+	for _, tt := range tests {
+		got, err := NewRacePredictor(tt.runs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: NewRacePredictor() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	for _, runs := range [][]Running{nil, {raceRun(0, time.Hour), raceRun(5, 0)}} {
+		if got, err := NewRacePredictor(runs); err == nil {
+			t.Errorf("NewRacePredictor(%v) = %+v, want error", runs, got)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stroke стиль плавания в записи серии.
+type Stroke string
+
+// Стили плавания, которые понимает ParseSwimSets.
+const (
+	StrokeFree   Stroke = "free"   // вольный стиль
+	StrokeBack   Stroke = "back"   // на спине
+	StrokeBreast Stroke = "breast" // брасс
+	StrokeFly    Stroke = "fly"    // баттерфляй
+	StrokeIM     Stroke = "im"     // комплексное плавание
+)
+
+// StrokeCaloriesMultiplier множитель калорий для стиля относительно вольного стиля.
+var StrokeCaloriesMultiplier = map[Stroke]float64{
+	StrokeFree:   1,
+	StrokeBack:   0.85,
+	StrokeBreast: 0.95,
+	StrokeFly:    1.4,
+	StrokeIM:     1.05,
+}
+
+// Ограничения записи серии, чтобы повторы, дистанция и интервал не переполняли int и time.Duration.
+const (
+	MaxSwimRepeats  = 1000          // наибольшее количество повторов в серии
+	MaxSwimDistance = 100000        // наибольшая дистанция одного повтора в м
+	MaxSwimInterval = 6 * time.Hour // наибольший интервал одного повтора
+)
+
+// SwimSet одна серия в записи тренировки, например 4×100 free @1:45.
+type SwimSet struct {
+	Repeats  int           // количество повторов
+	Distance int           // дистанция одного повтора в м
+	Stroke   Stroke        // стиль плавания
+	Interval time.Duration // время на один повтор вместе с отдыхом
+}
+
+// TotalDistance возвращает дистанцию всей серии в м.
+func (s SwimSet) TotalDistance() int {
+	return s.Repeats * s.Distance
+}
+
+// Duration возвращает продолжительность всей серии.
+func (s SwimSet) Duration() time.Duration {
+	return time.Duration(s.Repeats) * s.Interval
+}
+
+// Pace возвращает целевой темп серии на 100 м.
+func (s SwimSet) Pace() time.Duration {
+	if s.Distance == 0 {
+		return 0
+	}
+	return s.Interval * 100 / time.Duration(s.Distance)
+}
+
+// swimSetPattern разбирает одну серию: [повторы×]дистанция [стиль] @мм:сс.
+var swimSetPattern = regexp.MustCompile(`^(?:(\d+)\s*[x×х]\s*)?(\d+)\s*([a-z]*)\s*@\s*(\d+):([0-5]\d)$`)
+
+// ParseSwimSets разбирает запись тренировки в стандартной нотации, например
+// "4×100 free @1:45, 8×50 fly @1:00". Серии разделяются запятыми, стиль по умолчанию вольный.
+// Интервал обязателен, так как по нему считается продолжительность тренировки.
+func ParseSwimSets(notation string) ([]SwimSet, error) {
+	var sets []SwimSet
+	for _, part := range strings.Split(notation, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		m := swimSetPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("не удалось разобрать серию %q", part)
+		}
+
+		set := SwimSet{Repeats: 1, Stroke: StrokeFree}
+		var err error
+		if m[1] != "" {
+			if set.Repeats, err = strconv.Atoi(m[1]); err != nil {
+				return nil, fmt.Errorf("повторы в серии %q: %w", part, err)
+			}
+		}
+		if set.Distance, err = strconv.Atoi(m[2]); err != nil {
+			return nil, fmt.Errorf("дистанция в серии %q: %w", part, err)
+		}
+		if m[3] != "" {
+			set.Stroke = Stroke(m[3])
+		}
+		if _, ok := StrokeCaloriesMultiplier[set.Stroke]; !ok {
+			return nil, fmt.Errorf("неизвестный стиль плавания %q в серии %q", m[3], part)
+		}
+		minutes, err := strconv.Atoi(m[4])
+		if err != nil {
+			return nil, fmt.Errorf("интервал в серии %q: %w", part, err)
+		}
+		// секунды уже проверены шаблоном, в них две цифры
+		seconds, _ := strconv.Atoi(m[5])
+
+		switch {
+		case set.Repeats > MaxSwimRepeats:
+			return nil, fmt.Errorf("в серии %q больше %d повторов", part, MaxSwimRepeats)
+		case set.Distance > MaxSwimDistance:
+			return nil, fmt.Errorf("дистанция повтора в серии %q больше %d м", part, MaxSwimDistance)
+		case minutes > int(MaxSwimInterval/time.Minute):
+			return nil, fmt.Errorf("интервал в серии %q больше %v", part, MaxSwimInterval)
+		}
+		set.Interval = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+
+		if set.Repeats == 0 || set.Distance == 0 || set.Interval == 0 {
+			return nil, fmt.Errorf("серия %q должна иметь ненулевые повторы, дистанцию и интервал", part)
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// SwimSetInfo информация об одной серии тренировки по плаванию.
+type SwimSetInfo struct {
+	SwimSet
+	Speed    float64 // средняя скорость в км/ч
+	Calories float64 // количество потраченных килокалорий на серии
+}
+
+// SwimWorkout тренировка Плавание, составленная из серий.
+type SwimWorkout struct {
+	Swimming
+	Sets []SwimSet // серии в порядке выполнения
+}
+
+// NewSwimWorkout создает тренировку по записи серий для пользователя с весом weight в кг
+// в бассейне длиной lengthPool в м.
+func NewSwimWorkout(notation string, weight float64, lengthPool int) (SwimWorkout, error) {
+	sets, err := ParseSwimSets(notation)
+	if err != nil {
+		return SwimWorkout{}, err
+	}
+	if lengthPool <= 0 {
+		return SwimWorkout{}, fmt.Errorf("длина бассейна должна быть положительной, получено %d", lengthPool)
+	}
+
+	var distance int
+	var duration time.Duration
+	for _, set := range sets {
+		distance += set.TotalDistance()
+		duration += set.Duration()
+	}
+
+	return SwimWorkout{
+		Swimming: Swimming{
+			Training: Training{
+				TrainingType: "Плавание",
+				Action:       int(math.Round(float64(distance) / SwimmingLenStep)),
+				LenStep:      SwimmingLenStep,
+				Duration:     duration,
+				Weight:       weight,
+			},
+			LengthPool: lengthPool,
+			CountPool:  int(math.Round(float64(distance) / float64(lengthPool))),
+		},
+		Sets: sets,
+	}, nil
+}
+
+// SetsInfo возвращает скорость и калории для каждой серии.
+// Калории считаются по формуле Swimming.Calories() для серии и умножаются на StrokeCaloriesMultiplier.
+func (w SwimWorkout) SetsInfo() []SwimSetInfo {
+	infos := make([]SwimSetInfo, 0, len(w.Sets))
+	for _, set := range w.Sets {
+		part := w.Swimming
+		part.Duration = set.Duration()
+		speed := speedKmh(float64(set.TotalDistance())/MInKm, part.Duration)
+		infos = append(infos, SwimSetInfo{
+			SwimSet:  set,
+			Speed:    speed,
+			Calories: part.calories(speed) * StrokeCaloriesMultiplier[set.Stroke],
+		})
+	}
+	return infos
+}
+
+// Calories возвращает сумму калорий по всем сериям.
+// Это переопределенный метод Calories() из Swimming.
+func (w SwimWorkout) Calories() float64 {
+	var calories float64
+	for _, info := range w.SetsInfo() {
+		calories += info.Calories
+	}
+	return calories
+}
+
+// TrainingInfo возвращает структуру InfoMessage с информацией о тренировке,
+// дистанция и средняя скорость в которой считаются по сериям, а не по пересечениям бассейна,
+// так как дистанция серий может не делиться на длину бассейна.
+// Это переопределенный метод TrainingInfo() из Swimming.
+func (w SwimWorkout) TrainingInfo() InfoMessage {
+	var meters int
+	for _, set := range w.Sets {
+		meters += set.TotalDistance()
+	}
+	distance := float64(meters) / MInKm
+
+	info := w.info(distance, speedKmh(distance, w.Duration))
+	info.Calories = w.Calories()
+	return info
+}
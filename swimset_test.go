@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseSwimSetsRejectsOverflow(t *testing.T) {
+	for _, notation := range []string{
+		"99999999999999999999x100 @1:00",
+		"4x99999999999999999999 @1:00",
+		"4x100 @99999999999999999999:00",
+		"1001x100 @1:00",
+		"4x100001 @1:00",
+		"4x100 @361:00",
+	} {
+		if sets, err := ParseSwimSets(notation); err == nil {
+			t.Errorf("ParseSwimSets(%q) = %+v, want error", notation, sets)
+		}
+	}
+}
+
+func TestSwimWorkoutSpeedMatchesDistance(t *testing.T) {
+	// 4×100 + 2×60 = 520 м не делится на длину бассейна 50 м
+	w, err := NewSwimWorkout("4x100 free @2:00, 2x60 back @1:30", 70, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := w.TrainingInfo()
+	if want := 0.52; math.Abs(info.Distance-want) > 1e-9 {
+		t.Errorf("Distance = %v, want %v", info.Distance, want)
+	}
+	if want := info.Distance / info.Duration.Hours(); math.Abs(info.Speed-want) > 1e-9 {
+		t.Errorf("Speed = %v, want Distance/Duration = %v", info.Speed, want)
+	}
+	if info.Calories <= 0 {
+		t.Errorf("Calories = %v, want positive", info.Calories)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DefaultInfoTemplate шаблон, который выводит информацию о тренировке так же, как InfoMessage.String().
+const DefaultInfoTemplate = `Тип тренировки: {{.TrainingType}}
+Длительность: {{.Duration | min}} мин
+Дистанция: {{.Distance | km}} км.
+Ср. скорость: {{.Speed | kmh}} км/ч
+Потрачено ккал: {{.Calories | kcal}}
+{{if not .Fueling.IsZero}}Потеря жидкости: {{.Fueling.FluidLoss | fuel}} мл
+Углеводы: {{.Fueling.Carbs | fuel}} г
+{{end}}{{if .Estimated}}Оценено по профилю: {{.Estimated | estimated}}
+{{end}}`
+
+var (
+	templatesMu sync.RWMutex
+	templates   = map[string]*template.Template{
+		"": template.Must(ParseInfoTemplate("default", DefaultInfoTemplate, DefaultDisplayFormat)),
+	}
+)
+
+// TemplateFuncs возвращает функции форматирования для шаблонов информации о тренировке:
+// min - длительность в минутах, km - дистанция, kmh - скорость, kcal - калории,
+// fuel - потеря жидкости и углеводы, estimated - оцененные поля. Точность и разделитель берутся из f.
+func TemplateFuncs(f DisplayFormat) template.FuncMap {
+	return template.FuncMap{
+		"min":       func(d time.Duration) string { return f.Number(d.Minutes(), f.DurationPrecision) },
+		"km":        func(v float64) string { return f.Number(v, f.DistancePrecision) },
+		"kmh":       func(v float64) string { return f.Number(v, f.SpeedPrecision) },
+		"kcal":      func(v float64) string { return f.Number(v, f.CaloriesPrecision) },
+		"fuel":      func(v float64) string { return f.Number(v, f.FuelingPrecision) },
+		"estimated": func(e EstimatedFields) string { return string(e.appendTitles(nil)) },
+	}
+}
+
+// ParseInfoTemplate разбирает шаблон информации о тренировке с функциями TemplateFuncs(f).
+// В шаблон передается InfoMessage.
+func ParseInfoTemplate(name, text string, f DisplayFormat) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFuncs(f)).Parse(text)
+}
+
+// RegisterInfoTemplate задает шаблон информации о тренировке для языка locale, например "en".
+func RegisterInfoTemplate(locale string, tmpl *template.Template) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	templates[strings.ToLower(locale)] = tmpl
+}
+
+// InfoTemplate возвращает шаблон для языка locale. Если для "en-US" нет шаблона, ищется шаблон для "en",
+// а если нет и его, возвращается шаблон по умолчанию.
+func InfoTemplate(locale string) *template.Template {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	locale = strings.ToLower(locale)
+	if tmpl, ok := templates[locale]; ok {
+		return tmpl
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if tmpl, ok := templates[lang]; ok {
+		return tmpl
+	}
+	return templates[""]
+}
+
+// WriteInfoTemplate записывает в w информацию о проведенной тренировке по шаблону tmpl.
+func WriteInfoTemplate(w io.Writer, training CaloriesCalculator, tmpl *template.Template) error {
+	if err := tmpl.Execute(w, readInfo(training)); err != nil {
+		return fmt.Errorf("шаблон %s: %w", tmpl.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestDefaultInfoTemplateMatchesReadData(t *testing.T) {
+	running := Running{Training: Training{TrainingType: "Бег", Action: 15000, LenStep: LenStep, Duration: 90 * time.Minute, Weight: 70}}
+	withFueling := running
+	withFueling.Environment = &Environment{Temperature: 28}
+
+	for name, training := range map[string]CaloriesCalculator{
+		"without fueling": running,
+		"with fueling":    withFueling,
+		"swimming": Swimming{Training: Training{TrainingType: "Плавание", Action: 2000, LenStep: SwimmingLenStep, Duration: 90 * time.Minute, Weight: 85},
+			LengthPool: 50, CountPool: 5},
+	} {
+		var sb strings.Builder
+		if err := WriteInfoTemplate(&sb, training, InfoTemplate("")); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if want := ReadData(training); sb.String() != want {
+			t.Errorf("%s: WriteInfoTemplate() = %q, ReadData() = %q", name, sb.String(), want)
+		}
+	}
+	if !strings.Contains(ReadData(withFueling), "Потеря жидкости: ") {
+		t.Error("ReadData() with environment has no fueling lines")
+	}
+}
+
+func TestInfoTemplateFallback(t *testing.T) {
+	en := template.Must(ParseInfoTemplate("en", "Workout: {{.TrainingType}}", DefaultDisplayFormat))
+	enGB := template.Must(ParseInfoTemplate("en-GB", "Session: {{.TrainingType}}", DefaultDisplayFormat))
+	RegisterInfoTemplate("en", en)
+	RegisterInfoTemplate("en-GB", enGB)
+	t.Cleanup(func() {
+		templatesMu.Lock()
+		defer templatesMu.Unlock()
+		delete(templates, "en")
+		delete(templates, "en-gb")
+	})
+
+	tests := []struct {
+		locale string
+		want   *template.Template
+	}{
+		{"en", en},
+		{"en-US", en},
+		{"en_US", en},
+		{"EN-us", en},
+		{"en-GB", enGB},
+		{"en-gb", enGB},
+		{"ru-RU", templates[""]},
+		{"", templates[""]},
+	}
+	for _, tt := range tests {
+		if got := InfoTemplate(tt.locale); got != tt.want {
+			t.Errorf("InfoTemplate(%q) = %s, want %s", tt.locale, got.Name(), tt.want.Name())
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TrackEffort вид отрезка в записи беговой тренировки.
+type TrackEffort string
+
+// Виды отрезков, которые понимает ParseTrackWorkout.
+const (
+	TrackWork TrackEffort = "work" // рабочий отрезок
+	TrackJog  TrackEffort = "jog"  // восстановление трусцой
+	TrackWalk TrackEffort = "walk" // восстановление шагом
+)
+
+// Ограничения записи беговой тренировки, чтобы повторы, дистанция и время не переполняли int
+// и time.Duration, а развернутая тренировка помещалась в память.
+const (
+	MaxTrackRepeats   = 1000          // наибольшее количество повторов шага
+	MaxTrackDistance  = 100000        // наибольшая дистанция одного отрезка в м
+	MaxTrackTarget    = 6 * time.Hour // наибольшее целевое время одного отрезка
+	MaxTrackIntervals = 10000         // наибольшее количество отрезков в развернутой тренировке
+)
+
+// TrackStep шаг записи беговой тренировки: отрезок или повторяемая группа шагов.
+type TrackStep struct {
+	Repeats  int           // количество повторов
+	Distance int           // дистанция отрезка в м, 0 для группы
+	Target   time.Duration // целевое время отрезка, 0 - не задано
+	Effort   TrackEffort   // вид отрезка
+	Steps    []TrackStep   // шаги группы, nil для отрезка
+}
+
+// TrackInterval один отрезок развернутой беговой тренировки.
+type TrackInterval struct {
+	Distance int           // дистанция в м
+	Target   time.Duration // целевое время, 0 - не задано
+	Effort   TrackEffort   // вид отрезка
+}
+
+// TrackWorkout интервальная беговая тренировка, разобранная из записи.
+type TrackWorkout struct {
+	Steps []TrackStep // шаги в порядке выполнения
+}
+
+// Intervals возвращает отрезки тренировки с развернутыми повторами.
+func (w TrackWorkout) Intervals() []TrackInterval {
+	return expandTrackSteps(nil, w.Steps)
+}
+
+// TotalDistance возвращает дистанцию всей тренировки в м.
+func (w TrackWorkout) TotalDistance() int {
+	var distance int
+	for _, interval := range w.Intervals() {
+		distance += interval.Distance
+	}
+	return distance
+}
+
+// trackIntervalCount возвращает количество отрезков в steps с учетом повторов.
+func trackIntervalCount(steps []TrackStep) int {
+	var count int
+	for _, step := range steps {
+		if step.Steps != nil {
+			count += step.Repeats * trackIntervalCount(step.Steps)
+			continue
+		}
+		count += step.Repeats
+	}
+	return count
+}
+
+// expandTrackSteps добавляет в dst отрезки шагов steps с учетом повторов.
+func expandTrackSteps(dst []TrackInterval, steps []TrackStep) []TrackInterval {
+	for _, step := range steps {
+		for i := 0; i < step.Repeats; i++ {
+			if step.Steps != nil {
+				dst = expandTrackSteps(dst, step.Steps)
+				continue
+			}
+			dst = append(dst, TrackInterval{Distance: step.Distance, Target: step.Target, Effort: step.Effort})
+		}
+	}
+	return dst
+}
+
+// ParseTrackWorkout разбирает запись беговой тренировки, например "2×(4×400m @78s, 200m jog)".
+// Отрезок записывается как [повторы×]дистанция(m|km) [@время] [jog|walk], группа как повторы×(шаги).
+// Время задается в секундах (78s) или минутах и секундах (3:05).
+// Повторы, дистанция и время отрезка и количество отрезков ограничены константами MaxTrack*.
+func ParseTrackWorkout(notation string) (TrackWorkout, error) {
+	p := trackParser{s: []rune(strings.ToLower(notation))}
+	steps, err := p.list(0)
+	if err != nil {
+		return TrackWorkout{}, err
+	}
+	return TrackWorkout{Steps: steps}, nil
+}
+
+// trackParser рекурсивный разбор записи беговой тренировки.
+type trackParser struct {
+	s   []rune
+	pos int
+}
+
+// list разбирает шаги, разделенные запятыми, до закрывающего символа closing (0 - до конца строки).
+// Количество отрезков проверяется после каждого шага, поэтому вложенные группы не переполняют int.
+func (p *trackParser) list(closing rune) ([]TrackStep, error) {
+	var steps []TrackStep
+	for {
+		step, err := p.step()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		if trackIntervalCount(steps) > MaxTrackIntervals {
+			return nil, p.errorf("в тренировке больше %d отрезков", MaxTrackIntervals)
+		}
+
+		p.spaces()
+		switch {
+		case p.peek() == ',':
+			p.pos++
+		case p.peek() == closing:
+			if closing != 0 {
+				p.pos++
+			}
+			return steps, nil
+		default:
+			return nil, p.errorf("ожидалась запятая")
+		}
+	}
+}
+
+// step разбирает один отрезок или группу.
+func (p *trackParser) step() (TrackStep, error) {
+	p.spaces()
+	n, err := p.number()
+	if err != nil {
+		return TrackStep{}, err
+	}
+
+	step := TrackStep{Repeats: 1, Effort: TrackWork}
+	p.spaces()
+	if r := p.peek(); r == 'x' || r == '×' || r == 'х' {
+		p.pos++
+		if n < 1 || n > MaxTrackRepeats || n != math.Trunc(n) {
+			return TrackStep{}, p.errorf("количество повторов должно быть целым числом от 1 до %d", MaxTrackRepeats)
+		}
+		step.Repeats = int(n)
+
+		p.spaces()
+		if p.peek() == '(' {
+			p.pos++
+			if step.Steps, err = p.list(')'); err != nil {
+				return TrackStep{}, err
+			}
+			return step, nil
+		}
+		if n, err = p.number(); err != nil {
+			return TrackStep{}, err
+		}
+	}
+
+	switch {
+	case p.consume("km"):
+		n *= MInKm
+	case p.consume("m"):
+	default:
+		return TrackStep{}, p.errorf("ожидалась единица дистанции m или km")
+	}
+	if n > MaxTrackDistance {
+		return TrackStep{}, p.errorf("дистанция отрезка должна быть не больше %d м", MaxTrackDistance)
+	}
+	step.Distance = int(n)
+	if step.Distance <= 0 {
+		return TrackStep{}, p.errorf("дистанция отрезка должна быть положительной")
+	}
+
+	for {
+		p.spaces()
+		switch {
+		case p.consume("@"):
+			p.spaces()
+			if step.Target, err = p.duration(); err != nil {
+				return TrackStep{}, err
+			}
+		case p.consume(string(TrackJog)):
+			step.Effort = TrackJog
+		case p.consume(string(TrackWalk)):
+			step.Effort = TrackWalk
+		default:
+			return step, nil
+		}
+	}
+}
+
+// duration разбирает время в виде 78s или 3:05, не больше MaxTrackTarget.
+func (p *trackParser) duration() (time.Duration, error) {
+	n, err := p.number()
+	if err != nil {
+		return 0, err
+	}
+	if p.consume("s") {
+		return p.target(n)
+	}
+	if !p.consume(":") {
+		return 0, p.errorf("ожидалось время в виде 78s или 3:05")
+	}
+	seconds, err := p.number()
+	if err != nil {
+		return 0, err
+	}
+	if seconds >= 60 {
+		return 0, p.errorf("секунд в минуте должно быть меньше 60")
+	}
+	return p.target(math.Trunc(n)*60 + seconds)
+}
+
+// target возвращает целевое время отрезка для seconds секунд, если оно не больше MaxTrackTarget.
+// Проверка делается до перевода в time.Duration, чтобы большое значение не переполнило его.
+func (p *trackParser) target(seconds float64) (time.Duration, error) {
+	if seconds > MaxTrackTarget.Seconds() {
+		return 0, p.errorf("целевое время отрезка должно быть не больше %v", MaxTrackTarget)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// number разбирает неотрицательное число, возможно с дробной частью.
+func (p *trackParser) number() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (unicode.IsDigit(p.s[p.pos]) || p.s[p.pos] == '.') {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("ожидалось число")
+	}
+	n, err := strconv.ParseFloat(string(p.s[start:p.pos]), 64)
+	if err != nil {
+		return 0, p.errorf("неверное число %q", string(p.s[start:p.pos]))
+	}
+	return n, nil
+}
+
+// consume пропускает prefix, если запись продолжается им.
+func (p *trackParser) consume(prefix string) bool {
+	r := []rune(prefix)
+	if len(p.s)-p.pos < len(r) || string(p.s[p.pos:p.pos+len(r)]) != prefix {
+		return false
+	}
+	p.pos += len(r)
+	return true
+}
+
+// peek возвращает текущий символ или 0 в конце записи.
+func (p *trackParser) peek() rune {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// spaces пропускает пробелы.
+func (p *trackParser) spaces() {
+	for p.pos < len(p.s) && unicode.IsSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+// errorf возвращает ошибку разбора с позицией в записи.
+func (p *trackParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("позиция %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTrackWorkout(t *testing.T) {
+	w, err := ParseTrackWorkout("1.5km jog, 2×(4×400m @78s, 200m jog), 1km @3:05, 800m walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	intervals := w.Intervals()
+	if len(intervals) != 1+2*5+2 {
+		t.Fatalf("Intervals() = %+v, want 13 intervals", intervals)
+	}
+	if want := (TrackInterval{Distance: 1500, Effort: TrackJog}); intervals[0] != want {
+		t.Errorf("Intervals()[0] = %+v, want %+v", intervals[0], want)
+	}
+	if want := (TrackInterval{Distance: 400, Target: 78 * time.Second, Effort: TrackWork}); intervals[1] != want {
+		t.Errorf("Intervals()[1] = %+v, want %+v", intervals[1], want)
+	}
+	if want := (TrackInterval{Distance: 200, Effort: TrackJog}); intervals[10] != want {
+		t.Errorf("Intervals()[10] = %+v, want %+v", intervals[10], want)
+	}
+	if want := (TrackInterval{Distance: 1000, Target: 3*time.Minute + 5*time.Second, Effort: TrackWork}); intervals[11] != want {
+		t.Errorf("Intervals()[11] = %+v, want %+v", intervals[11], want)
+	}
+	if want := 1500 + 2*(4*400+200) + 1000 + 800; w.TotalDistance() != want {
+		t.Errorf("TotalDistance() = %d, want %d", w.TotalDistance(), want)
+	}
+}
+
+func TestParseTrackWorkoutLimits(t *testing.T) {
+	for _, notation := range []string{
+		"1000x100m",
+		"100x100m @360:00",
+		"400m @21600s",
+		"100km",
+		"10x(1000x400m)",
+	} {
+		if _, err := ParseTrackWorkout(notation); err != nil {
+			t.Errorf("ParseTrackWorkout(%q): %v, want no error at the limit", notation, err)
+		}
+	}
+}
+
+func TestParseTrackWorkoutRejectsOverflow(t *testing.T) {
+	for _, notation := range []string{
+		"3x9000000000000000km",
+		"400m @99999999999s",
+		"400m @99999999999:00",
+		"400m @21601s",
+		"100.001km",
+		"1001x400m",
+		"99999999999999999999x400m",
+		"100000x(100000x(100000x400m))",
+		"11x(1000x400m)",
+		"5000x400m, 5001x200m",
+		"0x400m",
+		"1.5x400m",
+		"400m @1:60",
+		"400",
+	} {
+		if w, err := ParseTrackWorkout(notation); err == nil {
+			t.Errorf("ParseTrackWorkout(%q) = %+v, want error", notation, w)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Константы для оценки МПК (максимального потребления кислорода) по формулам Дэниелса.
+const (
+	DanielsCostShift       = -4.60      // свободный член формулы кислородной стоимости бега
+	DanielsCostLinear      = 0.182258   // множитель скорости в м/мин
+	DanielsCostQuadratic   = 0.000104   // множитель квадрата скорости в м/мин
+	DanielsFractionBase    = 0.8        // доля МПК, которую можно держать неограниченно долго
+	DanielsFractionFirst   = 0.1894393  // множитель первой экспоненты доли МПК
+	DanielsFractionFirstK  = -0.012778  // показатель первой экспоненты в 1/мин
+	DanielsFractionSecond  = 0.2989558  // множитель второй экспоненты доли МПК
+	DanielsFractionSecondK = -0.1932605 // показатель второй экспоненты в 1/мин
+)
+
+// Константы для оценки МПК по пульсу (формулы ACSM и Суэйна).
+const (
+	RestingVO2           = 3.5 // потребление кислорода в покое в мл/кг/мин
+	RunningVO2PerMeter   = 0.2 // кислородная стоимость бега в мл/кг на метр
+	MinVO2MaxRunDuration = 5   // минимальная продолжительность бега в минутах для оценки по темпу
+)
+
+// EstimateVO2Max оценивает МПК пользователя в мл/кг/мин по тренировке Бег.
+// Если у тренировки задан средний пульс, а в профиле пульс в покое и возраст или максимальный пульс,
+// используется резерв пульса (доля резерва пульса равна доле резерва МПК):
+// (0.2 * скорость_в_м/мин) / ((пульс - пульс_в_покое) / (макс_пульс - пульс_в_покое)) + 3.5
+// Иначе МПК оценивается по темпу как VDOT Дэниелса:
+// кислородная_стоимость(скорость_в_м/мин) / доля_МПК(время_в_минутах)
+// Возвращает 0, если данных для оценки недостаточно.
+func EstimateVO2Max(w Running, p UserProfile) float64 {
+	minutes := w.Duration.Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	speed := w.distance() * MInKm / minutes
+
+	if reserve := p.heartRateReserve(w.AvgHeartRate); reserve > 0 {
+		return RunningVO2PerMeter*speed/reserve + RestingVO2
+	}
+
+	if minutes < MinVO2MaxRunDuration {
+		return 0
+	}
+	cost := DanielsCostShift + DanielsCostLinear*speed + DanielsCostQuadratic*speed*speed
+	fraction := DanielsFractionBase +
+		DanielsFractionFirst*math.Exp(DanielsFractionFirstK*minutes) +
+		DanielsFractionSecond*math.Exp(DanielsFractionSecondK*minutes)
+	return math.Max(0, cost/fraction)
+}
+
+// VO2MaxPoint оценка МПК по одной тренировке.
+type VO2MaxPoint struct {
+	StartedAt time.Time // время начала тренировки
+	VO2Max    float64   // оценка МПК в мл/кг/мин
+}
+
+// VO2MaxTrend изменение МПК по истории тренировок.
+type VO2MaxTrend struct {
+	Points  []VO2MaxPoint // оценки по тренировкам в порядке времени
+	PerWeek float64       // наклон линии тренда в мл/кг/мин за неделю
+}
+
+// EstimateVO2MaxTrend оценивает МПК по каждой пробежке с заданным временем начала
+// и строит линию тренда методом наименьших квадратов.
+// Пробежки без времени начала или без оценки пропускаются.
+func EstimateVO2MaxTrend(runs []Running, p UserProfile) VO2MaxTrend {
+	var trend VO2MaxTrend
+	for _, r := range runs {
+		if r.StartedAt.IsZero() {
+			continue
+		}
+		if v := EstimateVO2Max(r, p); v > 0 {
+			trend.Points = append(trend.Points, VO2MaxPoint{StartedAt: r.StartedAt, VO2Max: v})
+		}
+	}
+	sort.SliceStable(trend.Points, func(i, j int) bool {
+		return trend.Points[i].StartedAt.Before(trend.Points[j].StartedAt)
+	})
+	if len(trend.Points) < 2 {
+		return trend
+	}
+
+	const week = 7 * 24 * time.Hour
+	first := trend.Points[0].StartedAt
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range trend.Points {
+		x := float64(point.StartedAt.Sub(first)) / float64(week)
+		sumX += x
+		sumY += point.VO2Max
+		sumXY += x * point.VO2Max
+		sumXX += x * x
+	}
+	n := float64(len(trend.Points))
+	if d := n*sumXX - sumX*sumX; d != 0 {
+		trend.PerWeek = (n*sumXY - sumX*sumY) / d
+	}
+	return trend
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEstimateVO2Max(t *testing.T) {
+	hr := UserProfile{RestingHR: 50, MaxHR: 190}
+	tests := []struct {
+		name    string
+		run     Running
+		profile UserProfile
+		want    float64
+	}{
+		// VDOT Дэниелса для 5 км за 20:00 по таблицам равен 49.8
+		{"daniels 5 km", Running{Training: Training{Duration: 20 * time.Minute}, MeasuredDistance: 5}, UserProfile{}, 49.806},
+		// 10 км/ч = 166.7 м/мин при доле резерва пульса (150 - 50) / (190 - 50) = 5/7:
+		// 0.2 * 166.7 / (5/7) + 3.5 = 50.17
+		{"heart rate reserve", Running{Training: Training{Duration: time.Hour}, MeasuredDistance: 10, AvgHeartRate: 150}, hr, 50.167},
+		// максимальный пульс по возрасту 208 - 0.7 * 40 = 180, доля резерва (150 - 60) / (180 - 60) = 0.75
+		{"max heart rate by age", Running{Training: Training{Duration: time.Hour}, MeasuredDistance: 10, AvgHeartRate: 150},
+			UserProfile{RestingHR: 60, Age: 40}, 0.2*10000.0/60/0.75 + 3.5},
+		{"steps", Running{Training: Training{Action: 7692, LenStep: LenStep, Duration: 20 * time.Minute}}, UserProfile{}, 49.806},
+		{"pulse without resting hr uses pace", Running{Training: Training{Duration: 20 * time.Minute}, MeasuredDistance: 5, AvgHeartRate: 170},
+			UserProfile{MaxHR: 190}, 49.806},
+		{"pulse above max uses pace", Running{Training: Training{Duration: 20 * time.Minute}, MeasuredDistance: 5, AvgHeartRate: 195}, hr, 49.806},
+		{"too short for pace", Running{Training: Training{Duration: 4 * time.Minute}, MeasuredDistance: 1}, UserProfile{}, 0},
+		{"short with heart rate", Running{Training: Training{Duration: 4 * time.Minute}, MeasuredDistance: 1, AvgHeartRate: 120}, hr, 0.2*250/0.5 + 3.5},
+		{"zero duration", Running{MeasuredDistance: 5}, hr, 0},
+	}
+	for _, tt := range tests {
+		if got := EstimateVO2Max(tt.run, tt.profile); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("%s: EstimateVO2Max() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateVO2MaxTrend(t *testing.T) {
+	hr := UserProfile{RestingHR: 50, MaxHR: 190}
+	reserve := 5.0 / 7
+	start := time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+	// run возвращает часовую пробежку через weeks недель после start с МПК vo2max по резерву пульса
+	run := func(weeks int, vo2max float64) Running {
+		speed := (vo2max - RestingVO2) * reserve / RunningVO2PerMeter
+		return Running{
+			Training:         Training{Duration: time.Hour, StartedAt: start.AddDate(0, 0, 7*weeks)},
+			MeasuredDistance: speed * 60 / MInKm,
+			AvgHeartRate:     150,
+		}
+	}
+	undated := run(0, 60)
+	undated.StartedAt = time.Time{}
+	tooShort := Running{Training: Training{Duration: time.Minute, StartedAt: start.AddDate(0, 0, 1)}, MeasuredDistance: 0.3}
+
+	// МПК растет на 0.5 мл/кг/мин в неделю, пробежки переданы не по порядку
+	trend := EstimateVO2MaxTrend([]Running{run(2, 46), undated, run(0, 45), tooShort, run(4, 47), run(1, 45.5)}, hr)
+	if len(trend.Points) != 4 {
+		t.Fatalf("Points = %+v, want 4 dated runs with an estimate", trend.Points)
+	}
+	for i := 1; i < len(trend.Points); i++ {
+		if !trend.Points[i-1].StartedAt.Before(trend.Points[i].StartedAt) {
+			t.Errorf("Points = %+v, want sorted by start", trend.Points)
+		}
+	}
+	if math.Abs(trend.Points[0].VO2Max-45) > 1e-9 {
+		t.Errorf("Points[0].VO2Max = %v, want 45", trend.Points[0].VO2Max)
+	}
+	if math.Abs(trend.PerWeek-0.5) > 1e-9 {
+		t.Errorf("PerWeek = %v, want 0.5", trend.PerWeek)
+	}
+
+	if single := EstimateVO2MaxTrend([]Running{run(0, 45), undated}, hr); len(single.Points) != 1 || single.PerWeek != 0 {
+		t.Errorf("EstimateVO2MaxTrend() for one run = %+v, want one point without slope", single)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWalkingValidateBackpackWeight(t *testing.T) {
+	walking := Walking{Training: Training{Action: 10000, LenStep: LenStep, Duration: time.Hour, Weight: 70}, Height: 175}
+	for _, weight := range []float64{-1, MaxBackpackWeight + 1, math.NaN(), math.Inf(1)} {
+		walking.BackpackWeight = weight
+		if err := walking.Validate(); err == nil {
+			t.Errorf("Validate() with backpack %v = nil, want error", weight)
+		}
+	}
+	walking.BackpackWeight = MaxBackpackWeight
+	if err := walking.Validate(); err != nil {
+		t.Errorf("Validate() with backpack %v = %v, want nil", walking.BackpackWeight, err)
+	}
+}